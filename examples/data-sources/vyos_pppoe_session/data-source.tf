@@ -0,0 +1,7 @@
+data "vyos_pppoe_session" "wan" {
+  name = "pppoe0"
+}
+
+output "wan_address" {
+  value = data.vyos_pppoe_session.wan.connected ? data.vyos_pppoe_session.wan.local_address : null
+}
@@ -0,0 +1,9 @@
+# Performs "show interfaces"
+data "vyos_show" "interfaces" {
+  command = "interfaces"
+}
+
+# Address of eth0, e.g. assigned by DHCP
+output "wan_address" {
+  value = one([for i in data.vyos_show.interfaces.records : split(",", i.ip_address)[0] if i.interface == "eth0"])
+}
@@ -0,0 +1,15 @@
+data "vyos_system_info" "router" {}
+
+# Refuse to apply against the wrong router
+resource "terraform_data" "identity" {
+  lifecycle {
+    precondition {
+      condition     = data.vyos_system_info.router.hostname == "edge01"
+      error_message = "Connected to ${data.vyos_system_info.router.hostname} instead of edge01."
+    }
+  }
+}
+
+output "is_sagitta" {
+  value = startswith(data.vyos_system_info.router.version, "1.4")
+}
@@ -0,0 +1,13 @@
+data "vyos_vrrp_state" "lan" {
+  name = "LAN"
+}
+
+# Only change the primary router while it is master
+resource "terraform_data" "primary" {
+  lifecycle {
+    precondition {
+      condition     = data.vyos_vrrp_state.lan.state == "MASTER"
+      error_message = "LAN is ${data.vyos_vrrp_state.lan.state} on this router."
+    }
+  }
+}
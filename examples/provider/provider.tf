@@ -11,3 +11,30 @@ provider "vyos" {
   url = "https://vyos.local"
   key = "xxxxxxxxx"
 }
+
+# Several routers can be managed from one provider with `device` blocks,
+# resources select one with their `device` attribute.
+provider "vyos" {
+  alias = "fleet"
+
+  device {
+    name = "edge1"
+    url  = "https://edge1.local"
+    key  = "xxxxxxxxx"
+  }
+
+  device {
+    name = "edge2"
+    url  = "https://edge2.local"
+    key  = "xxxxxxxxx"
+  }
+}
+
+resource "vyos_config" "hostname" {
+  provider = vyos.fleet
+  for_each = toset(["edge1", "edge2"])
+
+  device = each.key
+  key    = "system host-name"
+  value  = each.key
+}
@@ -0,0 +1,19 @@
+provider "vyos" {
+  url       = "https://vyos.local"
+  key       = "xxxxxxxxx"
+  save_mode = "deferred"
+}
+
+resource "vyos_static_host_mapping" "mapping" {
+  host = "test.local"
+  ip   = "10.0.0.1"
+}
+
+# Saves once the mapping is set, and again on every apply
+resource "vyos_config_save" "save" {
+  triggers = {
+    mapping = vyos_static_host_mapping.mapping.ip
+  }
+
+  depends_on = [vyos_static_host_mapping.mapping]
+}
@@ -0,0 +1 @@
+terraform import vyos_firewall_zone.lan "firewall zone LAN"
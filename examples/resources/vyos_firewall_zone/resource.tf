@@ -0,0 +1,14 @@
+resource "vyos_firewall_zone" "lan" {
+  name           = "LAN"
+  interfaces     = ["eth1", "eth2"]
+  default_action = "drop"
+
+  intra_zone_filtering {
+    action = "accept"
+  }
+}
+
+resource "vyos_firewall_zone" "local" {
+  name       = "LOCAL"
+  local_zone = true
+}
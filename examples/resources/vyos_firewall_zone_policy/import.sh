@@ -0,0 +1 @@
+terraform import vyos_firewall_zone_policy.wan_to_lan "firewall zone LAN from WAN"
@@ -0,0 +1,17 @@
+resource "vyos_firewall_zone" "lan" {
+  name       = "LAN"
+  interfaces = ["eth1"]
+}
+
+resource "vyos_firewall_zone" "wan" {
+  name       = "WAN"
+  interfaces = ["eth0"]
+}
+
+# The rulesets WAN-LAN-4 and WAN-LAN-6 must exist in the config
+resource "vyos_firewall_zone_policy" "wan_to_lan" {
+  from_zone    = vyos_firewall_zone.wan.name
+  to_zone      = vyos_firewall_zone.lan.name
+  ipv4_ruleset = "WAN-LAN-4"
+  ipv6_ruleset = "WAN-LAN-6"
+}
@@ -0,0 +1 @@
+terraform import vyos_interface_bond.uplink "interfaces bonding bond0"
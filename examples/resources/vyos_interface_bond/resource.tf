@@ -0,0 +1,10 @@
+resource "vyos_interface_bond" "uplink" {
+  name        = "bond0"
+  description = "Uplink to the core switches"
+  mode        = "802.3ad"
+  hash_policy = "layer3+4"
+  lacp_rate   = "fast"
+  min_links   = 1
+  members     = ["eth2", "eth3"]
+  addresses   = ["192.0.2.2/29"]
+}
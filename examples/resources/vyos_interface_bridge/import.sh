@@ -0,0 +1 @@
+terraform import vyos_interface_bridge.lan "interfaces bridge br0"
@@ -0,0 +1,18 @@
+resource "vyos_interface_bridge" "lan" {
+  name        = "br0"
+  addresses   = ["10.0.0.1/24"]
+  stp         = true
+  enable_vlan = true
+
+  member {
+    interface     = "eth1"
+    native_vlan   = 10
+    allowed_vlans = ["10", "20-30"]
+  }
+
+  member {
+    interface = "eth4"
+    priority  = 16
+    cost      = 100
+  }
+}
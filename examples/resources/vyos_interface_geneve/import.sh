@@ -0,0 +1 @@
+terraform import vyos_interface_geneve.overlay gnv0
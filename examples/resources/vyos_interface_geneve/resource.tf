@@ -0,0 +1,6 @@
+resource "vyos_interface_geneve" "overlay" {
+  name      = "gnv0"
+  vni       = 100
+  remote    = "192.0.2.2"
+  addresses = ["10.100.0.1/24"]
+}
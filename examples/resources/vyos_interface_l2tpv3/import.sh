@@ -0,0 +1 @@
+terraform import vyos_interface_l2tpv3.pseudowire l2tpeth0
@@ -0,0 +1,11 @@
+resource "vyos_interface_l2tpv3" "pseudowire" {
+  name             = "l2tpeth0"
+  source_address   = "192.0.2.1"
+  remote           = "198.51.100.1"
+  tunnel_id        = 10
+  peer_tunnel_id   = 20
+  session_id       = 100
+  peer_session_id  = 200
+  source_port      = 5000
+  destination_port = 5000
+}
@@ -0,0 +1 @@
+terraform import vyos_interface_openvpn.remote_access vtun0
@@ -0,0 +1,27 @@
+resource "vyos_interface_openvpn" "remote_access" {
+  name        = "vtun0"
+  description = "Remote access"
+  mode        = "server"
+  protocol    = "udp"
+  local_port  = 1194
+
+  data_ciphers = ["aes256gcm"]
+
+  tls {
+    ca_certificates = ["office-ca"]
+    certificate     = "openvpn-server"
+    dh_params       = "dh-2048"
+  }
+
+  server {
+    subnet      = ["10.23.0.0/24"]
+    topology    = "subnet"
+    push_route  = ["10.0.0.0/16"]
+    name_server = ["10.0.0.53"]
+
+    client {
+      name = "laptop"
+      ip   = ["10.23.0.10"]
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_interface_pppoe.wan pppoe0
@@ -0,0 +1,27 @@
+variable "pppoe_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "vyos_interface_pppoe" "wan" {
+  name             = "pppoe0"
+  description      = "Uplink"
+  source_interface = "eth0.7"
+  username         = "customer@isp.example"
+  password         = var.pppoe_password
+
+  default_route_distance = 10
+  mtu                    = 1492
+  ipv6_autoconf          = true
+
+  dhcpv6_prefix_delegation {
+    id     = 0
+    length = 56
+
+    interface {
+      name    = "eth1"
+      sla_id  = 1
+      address = "1"
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_interface_tunnel.branch tun0
@@ -0,0 +1,10 @@
+resource "vyos_interface_tunnel" "branch" {
+  name           = "tun0"
+  description    = "GRE to branch"
+  encapsulation  = "gre"
+  source_address = "192.0.2.1"
+  remote         = "198.51.100.1"
+  key            = 10
+  mtu            = 1476
+  addresses      = ["10.255.0.1/30"]
+}
@@ -0,0 +1 @@
+terraform import vyos_interface_vxlan.overlay vxlan10
@@ -0,0 +1,7 @@
+resource "vyos_interface_vxlan" "overlay" {
+  name           = "vxlan10"
+  vni            = 10
+  source_address = "192.0.2.1"
+  remote         = ["192.0.2.2", "192.0.2.3"]
+  mtu            = 1450
+}
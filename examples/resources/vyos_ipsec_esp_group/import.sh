@@ -0,0 +1 @@
+terraform import vyos_ipsec_esp_group.wan WAN-ESP
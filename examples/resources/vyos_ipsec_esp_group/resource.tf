@@ -0,0 +1,12 @@
+resource "vyos_ipsec_esp_group" "wan" {
+  name     = "WAN-ESP"
+  mode     = "tunnel"
+  lifetime = 3600
+  pfs      = "dh-group14"
+
+  proposal {
+    number     = 10
+    encryption = "aes256gcm128"
+    hash       = "sha256"
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_ipsec_ike_group.wan WAN-IKE
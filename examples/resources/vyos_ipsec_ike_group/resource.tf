@@ -0,0 +1,18 @@
+resource "vyos_ipsec_ike_group" "wan" {
+  name         = "WAN-IKE"
+  key_exchange = "ikev2"
+  lifetime     = 28800
+
+  dead_peer_detection {
+    action   = "restart"
+    interval = 30
+    timeout  = 120
+  }
+
+  proposal {
+    number     = 10
+    encryption = "aes256gcm128"
+    hash       = "sha256"
+    dh_group   = "14"
+  }
+}
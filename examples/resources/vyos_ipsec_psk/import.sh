@@ -0,0 +1 @@
+terraform import vyos_ipsec_psk.branch BRANCH
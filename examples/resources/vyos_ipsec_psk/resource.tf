@@ -0,0 +1,10 @@
+variable "branch_psk" {
+  type      = string
+  sensitive = true
+}
+
+resource "vyos_ipsec_psk" "branch" {
+  name   = "BRANCH"
+  ids    = ["hq.example.com", "branch.example.com"]
+  secret = var.branch_psk
+}
@@ -0,0 +1 @@
+terraform import vyos_ipsec_site_to_site_peer.branch BRANCH
@@ -0,0 +1,21 @@
+resource "vyos_ipsec_site_to_site_peer" "branch" {
+  name              = "BRANCH"
+  local_address     = "192.0.2.1"
+  remote_address    = "198.51.100.1"
+  ike_group         = vyos_ipsec_ike_group.wan.name
+  default_esp_group = vyos_ipsec_esp_group.wan.name
+
+  authentication {
+    mode      = "pre-shared-secret"
+    local_id  = "hq.example.com"
+    remote_id = "branch.example.com"
+  }
+
+  tunnel {
+    number        = 1
+    local_prefix  = ["10.0.0.0/16"]
+    remote_prefix = ["10.1.0.0/16"]
+  }
+
+  depends_on = [vyos_ipsec_psk.branch]
+}
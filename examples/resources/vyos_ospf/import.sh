@@ -0,0 +1 @@
+terraform import vyos_ospf.this "protocols ospf"
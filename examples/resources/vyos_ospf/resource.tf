@@ -0,0 +1,19 @@
+resource "vyos_ospf" "this" {
+  router_id                 = "192.0.2.1"
+  reference_bandwidth       = 10000
+  passive_interface_default = true
+
+  default_information_originate {
+    always      = true
+    metric_type = 2
+  }
+
+  redistribute {
+    protocol = "connected"
+  }
+}
+
+resource "vyos_ospf" "v6" {
+  protocol  = "ospfv3"
+  router_id = "192.0.2.1"
+}
@@ -0,0 +1 @@
+terraform import vyos_ospf_area.backbone "protocols ospf area 0"
@@ -0,0 +1,15 @@
+resource "vyos_ospf_area" "backbone" {
+  area_id        = "0"
+  networks       = ["10.0.0.0/24", "10.0.1.0/24"]
+  authentication = "md5"
+}
+
+resource "vyos_ospf_area" "branch" {
+  area_id    = "10"
+  area_type  = "stub"
+  no_summary = true
+
+  range {
+    prefix = "10.10.0.0/16"
+  }
+}
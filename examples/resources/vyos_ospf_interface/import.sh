@@ -0,0 +1 @@
+terraform import vyos_ospf_interface.eth1 "protocols ospf interface eth1"
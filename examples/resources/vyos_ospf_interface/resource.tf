@@ -0,0 +1,15 @@
+resource "vyos_ospf_interface" "eth1" {
+  name           = "eth1"
+  area           = "0"
+  cost           = 10
+  network        = "point-to-point"
+  hello_interval = 5
+  dead_interval  = 20
+
+  authentication {
+    md5_key {
+      key_id = 1
+      key    = var.ospf_key
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_pki_ca.office office-ca
@@ -0,0 +1,4 @@
+resource "vyos_pki_ca" "office" {
+  name        = "office-ca"
+  certificate = tls_self_signed_cert.ca.cert_pem
+}
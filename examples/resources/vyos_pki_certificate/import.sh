@@ -0,0 +1 @@
+terraform import vyos_pki_certificate.openvpn openvpn-server
@@ -0,0 +1,9 @@
+resource "vyos_pki_certificate" "openvpn" {
+  name        = "openvpn-server"
+  certificate = tls_locally_signed_cert.openvpn.cert_pem
+  private_key = tls_private_key.openvpn.private_key_pem
+}
+
+output "openvpn_fingerprint" {
+  value = vyos_pki_certificate.openvpn.fingerprint
+}
@@ -0,0 +1 @@
+terraform import vyos_pki_key_pair.peer peer-rsa
@@ -0,0 +1,5 @@
+resource "vyos_pki_key_pair" "peer" {
+  name        = "peer-rsa"
+  public_key  = tls_private_key.peer.public_key_pem
+  private_key = tls_private_key.peer.private_key_pem
+}
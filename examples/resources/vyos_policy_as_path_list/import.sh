@@ -0,0 +1 @@
+terraform import vyos_policy_as_path_list.transit "policy as-path-list TRANSIT"
@@ -0,0 +1,9 @@
+resource "vyos_policy_as_path_list" "transit" {
+  name = "TRANSIT"
+
+  rule {
+    number = 10
+    action = "permit"
+    regex  = "^64500_"
+  }
+}
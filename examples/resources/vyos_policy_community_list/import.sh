@@ -0,0 +1 @@
+terraform import vyos_policy_community_list.blackhole "policy community-list BLACKHOLE"
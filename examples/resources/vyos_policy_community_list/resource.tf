@@ -0,0 +1,9 @@
+resource "vyos_policy_community_list" "blackhole" {
+  name = "BLACKHOLE"
+
+  rule {
+    number = 10
+    action = "permit"
+    regex  = "65535:666"
+  }
+}
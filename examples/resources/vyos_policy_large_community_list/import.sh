@@ -0,0 +1 @@
+terraform import vyos_policy_large_community_list.region "policy large-community-list REGION"
@@ -0,0 +1,9 @@
+resource "vyos_policy_large_community_list" "region" {
+  name = "REGION"
+
+  rule {
+    number = 10
+    action = "permit"
+    regex  = "65000:1:.*"
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_policy_local_route.this "policy local-route"
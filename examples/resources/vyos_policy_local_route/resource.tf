@@ -0,0 +1,7 @@
+resource "vyos_policy_local_route" "this" {
+  rule {
+    number    = 10
+    source    = "203.0.113.2/32"
+    set_table = vyos_route_table.isp2.table
+  }
+}
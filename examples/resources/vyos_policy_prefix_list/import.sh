@@ -0,0 +1 @@
+terraform import vyos_policy_prefix_list.customers "policy prefix-list CUSTOMERS"
@@ -0,0 +1,28 @@
+resource "vyos_policy_prefix_list" "customers" {
+  name = "CUSTOMERS"
+
+  rule {
+    number = 10
+    action = "permit"
+    prefix = "198.51.100.0/22"
+    le     = 24
+  }
+
+  rule {
+    number = 20
+    action = "permit"
+    prefix = "203.0.113.0/24"
+  }
+}
+
+resource "vyos_policy_prefix_list" "customers6" {
+  type = "prefix-list6"
+  name = "CUSTOMERS6"
+
+  rule {
+    number = 10
+    action = "permit"
+    prefix = "2001:db8::/32"
+    le     = 48
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_policy_route.guests "policy route GUESTS"
@@ -0,0 +1,17 @@
+resource "vyos_policy_route" "guests" {
+  name       = "GUESTS"
+  interfaces = ["eth2"]
+
+  rule {
+    number              = 10
+    description         = "Keep local traffic local"
+    destination_address = "10.0.0.0/8"
+    set_table           = "main"
+  }
+
+  rule {
+    number         = 20
+    source_address = "10.20.0.0/24"
+    set_table      = vyos_route_table.isp2.table
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_policy_route_map.transit_in "policy route-map TRANSIT-IN"
@@ -0,0 +1,35 @@
+resource "vyos_policy_route_map" "transit_in" {
+  name = "TRANSIT-IN"
+
+  rule {
+    number = 10
+    action = "deny"
+
+    match {
+      ip_address_prefix_list = vyos_policy_prefix_list.customers.name
+    }
+  }
+
+  rule {
+    number = 20
+    action = "permit"
+
+    match {
+      community = vyos_policy_community_list.blackhole.name
+    }
+
+    set {
+      ip_next_hop   = "192.0.2.1"
+      community_add = ["no-export"]
+    }
+  }
+
+  rule {
+    number = 100
+    action = "permit"
+
+    set {
+      local_preference = 80
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_qos_cake.wan "qos policy cake WAN-CAKE"
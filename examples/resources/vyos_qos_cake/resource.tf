@@ -0,0 +1,12 @@
+resource "vyos_qos_cake" "wan" {
+  name               = "WAN-CAKE"
+  bandwidth          = "50mbit"
+  rtt                = 30
+  flow_isolation     = "dual-src-host"
+  flow_isolation_nat = true
+}
+
+resource "vyos_qos_interface" "wan" {
+  interface = "pppoe0"
+  egress    = vyos_qos_cake.wan.name
+}
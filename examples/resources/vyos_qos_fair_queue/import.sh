@@ -0,0 +1 @@
+terraform import vyos_qos_fair_queue.lan "qos policy fair-queue LAN-FQ"
@@ -0,0 +1,5 @@
+resource "vyos_qos_fair_queue" "lan" {
+  name          = "LAN-FQ"
+  hash_interval = 10
+  queue_limit   = 127
+}
@@ -0,0 +1 @@
+terraform import vyos_qos_fq_codel.lan "qos policy fq-codel LAN-CODEL"
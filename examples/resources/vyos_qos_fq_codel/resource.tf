@@ -0,0 +1,4 @@
+resource "vyos_qos_fq_codel" "lan" {
+  name   = "LAN-CODEL"
+  target = 5
+}
@@ -0,0 +1 @@
+terraform import vyos_qos_interface.wan "qos interface eth0"
@@ -0,0 +1,5 @@
+resource "vyos_qos_interface" "wan" {
+  interface = "eth0"
+  egress    = "WAN-OUT"
+  ingress   = "WAN-IN"
+}
@@ -0,0 +1 @@
+terraform import vyos_qos_limiter.guest_in "qos policy limiter GUEST-IN"
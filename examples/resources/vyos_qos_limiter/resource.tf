@@ -0,0 +1,25 @@
+resource "vyos_qos_limiter" "guest_in" {
+  name = "GUEST-IN"
+
+  class {
+    id        = 10
+    bandwidth = "5mbit"
+
+    match {
+      name = "downloads"
+
+      ip {
+        protocol = "tcp"
+      }
+    }
+  }
+
+  default {
+    bandwidth = "20mbit"
+  }
+}
+
+resource "vyos_qos_interface" "guest" {
+  interface = "eth2"
+  ingress   = vyos_qos_limiter.guest_in.name
+}
@@ -0,0 +1 @@
+terraform import vyos_qos_shaper.wan_out "qos policy shaper WAN-OUT"
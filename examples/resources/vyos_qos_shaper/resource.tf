@@ -0,0 +1,54 @@
+resource "vyos_qos_shaper" "wan_out" {
+  name      = "WAN-OUT"
+  bandwidth = "100mbit"
+
+  class {
+    id          = 10
+    description = "Voice"
+    bandwidth   = "10%"
+    priority    = 0
+    queue_type  = "priority"
+
+    match {
+      name = "sip"
+
+      ip {
+        dscp = "ef"
+      }
+    }
+  }
+
+  class {
+    id         = 20
+    bandwidth  = "50%"
+    ceiling    = "100%"
+    queue_type = "fq-codel"
+
+    match {
+      name = "servers"
+
+      ip {
+        source_address = "10.0.10.0/24"
+      }
+    }
+
+    match {
+      name = "servers-v6"
+
+      ipv6 {
+        source_address = "2001:db8:10::/64"
+      }
+    }
+  }
+
+  default {
+    bandwidth  = "20%"
+    ceiling    = "100%"
+    queue_type = "fq-codel"
+  }
+}
+
+resource "vyos_qos_interface" "wan" {
+  interface = "eth0"
+  egress    = vyos_qos_shaper.wan_out.name
+}
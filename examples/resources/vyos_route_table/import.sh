@@ -0,0 +1 @@
+terraform import vyos_route_table.isp2 "protocols static table 10"
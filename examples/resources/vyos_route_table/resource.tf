@@ -0,0 +1,20 @@
+resource "vyos_route_table" "isp2" {
+  table       = 10
+  description = "Second uplink"
+
+  route {
+    destination = "0.0.0.0/0"
+
+    next_hop {
+      address = "203.0.113.1"
+    }
+  }
+
+  route {
+    destination = "10.0.0.0/8"
+
+    interface {
+      name = "eth1"
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_service_ntp.ntp "service ntp"
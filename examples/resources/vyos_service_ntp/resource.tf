@@ -0,0 +1,14 @@
+resource "vyos_service_ntp" "ntp" {
+  server {
+    address = "pool.ntp.org"
+    pool    = true
+  }
+
+  server {
+    address = "10.0.0.1"
+    prefer  = true
+  }
+
+  allow_client   = ["10.0.0.0/8"]
+  listen_address = ["10.0.0.254"]
+}
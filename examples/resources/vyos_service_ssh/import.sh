@@ -0,0 +1 @@
+terraform import vyos_service_ssh.ssh "service ssh"
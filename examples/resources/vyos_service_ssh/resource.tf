@@ -0,0 +1,10 @@
+resource "vyos_service_ssh" "ssh" {
+  port                            = ["22"]
+  listen_address                  = ["192.168.1.1"]
+  disable_password_authentication = true
+
+  dynamic_protection {
+    threshold  = 30
+    block_time = 120
+  }
+}
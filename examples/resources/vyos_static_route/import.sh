@@ -0,0 +1 @@
+terraform import vyos_static_route.default "0.0.0.0/0"
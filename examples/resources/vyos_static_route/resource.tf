@@ -0,0 +1,17 @@
+resource "vyos_static_route" "default" {
+  destination = "0.0.0.0/0"
+
+  next_hop {
+    address = "203.0.113.1"
+  }
+}
+
+resource "vyos_static_route" "red_office" {
+  vrf         = vyos_vrf.red.name
+  destination = "10.20.0.0/16"
+
+  next_hop {
+    address  = "10.100.0.254"
+    distance = 10
+  }
+}
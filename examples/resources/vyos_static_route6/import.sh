@@ -0,0 +1 @@
+terraform import vyos_static_route6.discard "vrf name RED protocols static route6 2001:db8:ff::/48"
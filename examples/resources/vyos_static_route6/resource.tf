@@ -0,0 +1,14 @@
+resource "vyos_static_route6" "default" {
+  destination = "::/0"
+
+  next_hop {
+    address   = "fe80::1"
+    interface = "eth0"
+  }
+}
+
+resource "vyos_static_route6" "discard" {
+  vrf         = vyos_vrf.red.name
+  destination = "2001:db8:ff::/48"
+  blackhole   = true
+}
@@ -0,0 +1 @@
+terraform import vyos_system.system system
@@ -0,0 +1,8 @@
+resource "vyos_system" "system" {
+  host_name    = "edge1"
+  domain_name  = "example.com"
+  name_servers = ["1.1.1.1", "9.9.9.9"]
+  time_zone    = "Europe/Berlin"
+
+  login_banner_pre_login = "Authorized access only"
+}
@@ -0,0 +1 @@
+terraform import vyos_system_syslog.syslog "system syslog"
@@ -0,0 +1,17 @@
+resource "vyos_system_syslog" "syslog" {
+  local_facility {
+    name  = "all"
+    level = "info"
+  }
+
+  remote {
+    address  = "10.0.0.10"
+    port     = 514
+    protocol = "tcp"
+
+    facility {
+      name  = "all"
+      level = "notice"
+    }
+  }
+}
@@ -0,0 +1 @@
+terraform import vyos_system_user.operator operator
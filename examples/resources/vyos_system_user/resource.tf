@@ -0,0 +1,18 @@
+variable "operator_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "vyos_system_user" "operator" {
+  username  = "operator"
+  full_name = "Network Operator"
+
+  plaintext_password_wo         = var.operator_password
+  plaintext_password_wo_version = 1
+
+  ssh_keys = [
+    "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHsnQ4I0eXz7t8m6zEJ4yD0ly4k2zw8lWcKj3Ks1tC6d operator@laptop",
+    "from=\"10.0.0.0/8\" ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIK0BrLuJqQDj3i7NTTcDFGNPLZ4d9H2H8LfQ3qxCWmQo ci",
+  ]
+}
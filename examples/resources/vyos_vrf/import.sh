@@ -0,0 +1 @@
+terraform import vyos_vrf.red "vrf name RED"
@@ -0,0 +1,24 @@
+resource "vyos_vrf" "red" {
+  name        = "RED"
+  table       = 100
+  description = "Tenant red"
+
+  route {
+    destination = "0.0.0.0/0"
+
+    next_hop {
+      address = "10.100.0.1"
+    }
+  }
+}
+
+resource "vyos_ospf" "red" {
+  vrf       = vyos_vrf.red.name
+  router_id = "10.100.0.2"
+}
+
+resource "vyos_ospf_area" "red_backbone" {
+  vrf      = vyos_vrf.red.name
+  area_id  = "0"
+  networks = ["10.100.0.0/24"]
+}
@@ -0,0 +1 @@
+terraform import vyos_vrrp_group.lan "high-availability vrrp group LAN"
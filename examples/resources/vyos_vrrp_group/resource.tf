@@ -0,0 +1,24 @@
+resource "vyos_vrrp_group" "lan" {
+  name          = "LAN"
+  interface     = "eth1"
+  vrid          = 10
+  addresses     = ["10.0.0.1/24"]
+  priority      = 200
+  preempt_delay = 30
+
+  authentication {
+    type     = "plaintext-password"
+    password = var.vrrp_password
+  }
+
+  health_check {
+    script        = "/config/scripts/check-uplink.sh"
+    interval      = 5
+    failure_count = 3
+  }
+
+  transition_script {
+    master = "/config/scripts/vrrp-master.sh"
+    backup = "/config/scripts/vrrp-backup.sh"
+  }
+}
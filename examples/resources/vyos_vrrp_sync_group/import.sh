@@ -0,0 +1 @@
+terraform import vyos_vrrp_sync_group.main "high-availability vrrp sync-group MAIN"
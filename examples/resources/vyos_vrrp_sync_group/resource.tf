@@ -0,0 +1,4 @@
+resource "vyos_vrrp_sync_group" "main" {
+  name    = "MAIN"
+  members = [vyos_vrrp_group.lan.name, vyos_vrrp_group.wan.name]
+}
@@ -0,0 +1,229 @@
+// Package interfacedef loads the VyOS interface-definition XML files which
+// describe every configuration node the router accepts.
+//
+// The definitions are the ones built by vyos-1x (build/interface-definitions
+// or /usr/share/vyos/interface-definitions on a router). Several files may
+// define parts of the same node, so everything is merged into a single tree.
+package interfacedef
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type Kind int
+
+const (
+	KindNode Kind = iota
+	KindTagNode
+	KindLeafNode
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindTagNode:
+		return "tag node"
+	case KindLeafNode:
+		return "leaf node"
+	default:
+		return "node"
+	}
+}
+
+// Node is a single configuration node, e.g. "service ssh port".
+type Node struct {
+	Name      string
+	Kind      Kind
+	Help      string
+	Valueless bool
+	Multi     bool
+	Default   string
+
+	// Constraint restricts leaf values and tag node names, nil if unconstrained.
+	Constraint *Constraint
+
+	Children map[string]*Node
+}
+
+// Constraint is satisfied when any of its regexes or validators accept the value.
+type Constraint struct {
+	Regexes    []*regexp.Regexp
+	Validators []Validator
+	Message    string
+
+	// Set when a regex could not be compiled or a validator is not
+	// implemented, in which case the constraint can not be checked locally.
+	unchecked bool
+}
+
+type Validator struct {
+	Name     string
+	Argument string
+}
+
+// ChildNames returns the names of all children, sorted.
+func (n *Node) ChildNames() []string {
+	names := make([]string, 0, len(n.Children))
+	for name := range n.Children {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type xmlDefinition struct {
+	Nodes []xmlNode `xml:",any"`
+}
+
+type xmlNode struct {
+	XMLName      xml.Name
+	Name         string        `xml:"name,attr"`
+	Properties   xmlProperties `xml:"properties"`
+	DefaultValue string        `xml:"defaultValue"`
+	Children     *xmlChildren  `xml:"children"`
+}
+
+type xmlChildren struct {
+	Nodes []xmlNode `xml:",any"`
+}
+
+type xmlProperties struct {
+	Help                   string         `xml:"help"`
+	Valueless              *struct{}      `xml:"valueless"`
+	Multi                  *struct{}      `xml:"multi"`
+	Constraint             *xmlConstraint `xml:"constraint"`
+	ConstraintErrorMessage string         `xml:"constraintErrorMessage"`
+}
+
+type xmlConstraint struct {
+	Regexes    []string `xml:"regex"`
+	Validators []struct {
+		Name     string `xml:"name,attr"`
+		Argument string `xml:"argument,attr"`
+	} `xml:"validator"`
+}
+
+// New returns an empty definition tree.
+func New() *Node {
+	return &Node{Children: map[string]*Node{}}
+}
+
+// Load reads a single definition file, or every *.xml file in a directory.
+func Load(path string) (*Node, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.xml"))
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no interface definitions found in %s", path)
+		}
+		sort.Strings(files)
+	}
+
+	root := New()
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		err = root.Parse(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return root, nil
+}
+
+// Parse reads one interfaceDefinition document and merges it into n.
+func (n *Node) Parse(r io.Reader) error {
+	var def xmlDefinition
+	if err := xml.NewDecoder(r).Decode(&def); err != nil {
+		return err
+	}
+	for _, child := range def.Nodes {
+		if err := n.merge(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Node) merge(x xmlNode) error {
+	var kind Kind
+	switch x.XMLName.Local {
+	case "node":
+		kind = KindNode
+	case "tagNode":
+		kind = KindTagNode
+	case "leafNode":
+		kind = KindLeafNode
+	default:
+		// Not a config node, e.g. a stray <syntaxVersion> element
+		return nil
+	}
+
+	child, ok := n.Children[x.Name]
+	if !ok {
+		child = &Node{Name: x.Name, Kind: kind, Children: map[string]*Node{}}
+		n.Children[x.Name] = child
+	} else if child.Kind != kind {
+		return fmt.Errorf("node %q defined as both %s and %s", x.Name, child.Kind, kind)
+	}
+
+	props := x.Properties
+	if child.Help == "" {
+		child.Help = strings.TrimSpace(props.Help)
+	}
+	if child.Default == "" {
+		child.Default = strings.TrimSpace(x.DefaultValue)
+	}
+	child.Valueless = child.Valueless || props.Valueless != nil
+	child.Multi = child.Multi || props.Multi != nil
+	if props.Constraint != nil && child.Constraint == nil {
+		child.Constraint = newConstraint(props.Constraint, strings.TrimSpace(props.ConstraintErrorMessage))
+	}
+
+	if x.Children != nil {
+		for _, c := range x.Children.Nodes {
+			if err := child.merge(c); err != nil {
+				return fmt.Errorf("%s: %w", x.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func newConstraint(x *xmlConstraint, message string) *Constraint {
+	c := &Constraint{Message: message}
+	for _, expr := range x.Regexes {
+		// VyOS matches constraint regexes against the whole value
+		re, err := regexp.Compile("^(?:" + strings.TrimSpace(expr) + ")$")
+		if err != nil {
+			// Python syntax RE2 does not support, e.g. lookaheads
+			c.unchecked = true
+			continue
+		}
+		c.Regexes = append(c.Regexes, re)
+	}
+	for _, v := range x.Validators {
+		if _, ok := validators[v.Name]; !ok {
+			c.unchecked = true
+		}
+		c.Validators = append(c.Validators, Validator{v.Name, strings.TrimSpace(v.Argument)})
+	}
+	return c
+}
@@ -0,0 +1,198 @@
+package interfacedef
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Check verifies that `set <path>` would be accepted by the definitions.
+// Like the VyOS API, a leaf value is passed as the last element of path.
+// It returns the deepest node reached.
+func (n *Node) Check(path []string) (*Node, error) {
+	node := n
+	tagged := false
+
+	for i, word := range path {
+		where := strings.Join(path[:i], " ")
+
+		switch {
+		case node.Kind == KindLeafNode:
+			if node.Valueless {
+				return node, fmt.Errorf("'%s' does not take a value", where)
+			}
+			if i != len(path)-1 {
+				return node, fmt.Errorf("'%s' takes a single value, got '%s'", where, strings.Join(path[i:], " "))
+			}
+			if err := node.Constraint.check(word); err != nil {
+				return node, fmt.Errorf("invalid value '%s' for '%s': %w", word, where, err)
+			}
+			return node, nil
+
+		case node.Kind == KindTagNode && !tagged:
+			if err := node.Constraint.check(word); err != nil {
+				return node, fmt.Errorf("invalid name '%s' for '%s': %w", word, where, err)
+			}
+			tagged = true
+
+		default:
+			child, ok := node.Children[word]
+			if !ok {
+				if where == "" {
+					return node, fmt.Errorf("unknown configuration node '%s'", word)
+				}
+				return node, fmt.Errorf("unknown configuration node '%s' under '%s'", word, where)
+			}
+			node = child
+			tagged = false
+		}
+	}
+
+	if node.Kind == KindLeafNode && !node.Valueless {
+		return node, fmt.Errorf("'%s' requires a value", strings.Join(path, " "))
+	}
+	return node, nil
+}
+
+func (c *Constraint) check(value string) error {
+	if c == nil || c.unchecked {
+		return nil
+	}
+	for _, re := range c.Regexes {
+		if re.MatchString(value) {
+			return nil
+		}
+	}
+	for _, v := range c.Validators {
+		if validators[v.Name](value, v.Argument) {
+			return nil
+		}
+	}
+	if c.Message != "" {
+		return fmt.Errorf("%s", c.Message)
+	}
+	return fmt.Errorf("value does not match the node constraints")
+}
+
+// Local implementations of the vyos-1x validator scripts most commonly
+// referenced by the definitions. Constraints referencing any other
+// validator are not checked.
+var validators = map[string]func(value, argument string) bool{
+	"numeric":      validateNumeric,
+	"ipv4-address": func(v, _ string) bool { return isAddress(v, 4) },
+	"ipv6-address": func(v, _ string) bool { return isAddress(v, 6) },
+	"ip-address":   func(v, _ string) bool { return isAddress(v, 0) },
+	"ipv4-prefix":  func(v, _ string) bool { return isPrefix(v, 4, true) },
+	"ipv6-prefix":  func(v, _ string) bool { return isPrefix(v, 6, true) },
+	"ip-prefix":    func(v, _ string) bool { return isPrefix(v, 0, true) },
+	"ipv4-host":    func(v, _ string) bool { return isPrefix(v, 4, false) },
+	"ipv6-host":    func(v, _ string) bool { return isPrefix(v, 6, false) },
+	"ip-host":      func(v, _ string) bool { return isPrefix(v, 0, false) },
+	"mac-address":  func(v, _ string) bool { return macAddress.MatchString(v) },
+	"fqdn":         func(v, _ string) bool { return fqdn.MatchString(v) },
+}
+
+// isAddress reports whether v is an address of the given IP version, 0 meaning either.
+func isAddress(v string, version int) bool {
+	addr, err := netip.ParseAddr(v)
+	return err == nil && isVersion(addr, version)
+}
+
+// isPrefix reports whether v is an address/length of the given IP version.
+// If network is set, no host bits may be set.
+func isPrefix(v string, version int, network bool) bool {
+	prefix, err := netip.ParsePrefix(v)
+	if err != nil || !isVersion(prefix.Addr(), version) {
+		return false
+	}
+	return !network || prefix == prefix.Masked()
+}
+
+func isVersion(addr netip.Addr, version int) bool {
+	switch version {
+	case 4:
+		return addr.Is4()
+	case 6:
+		return addr.Is6()
+	default:
+		return true
+	}
+}
+
+var (
+	macAddress = regexp.MustCompile(`^[0-9a-fA-F]{2}(:[0-9a-fA-F]{2}){5}$`)
+	fqdn       = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+)
+
+// validateNumeric mirrors the vyos-1x numeric validator, accepting
+// --non-negative, --positive, --float, --allow-range and any number of
+// --range a-b.
+func validateNumeric(value, argument string) bool {
+	args := strings.Fields(argument)
+	allowRange, allowFloat := false, false
+	for _, arg := range args {
+		switch arg {
+		case "--allow-range":
+			allowRange = true
+		case "--float":
+			allowFloat = true
+		}
+	}
+
+	numbers := []string{value}
+	if allowRange && strings.Contains(value, "-") {
+		numbers = strings.SplitN(value, "-", 2)
+	}
+
+	for _, number := range numbers {
+		x, err := strconv.ParseFloat(number, 64)
+		if err != nil || (!allowFloat && x != float64(int64(x))) {
+			return false
+		}
+		if !numericInBounds(x, args) {
+			return false
+		}
+	}
+	return true
+}
+
+func numericInBounds(x float64, args []string) bool {
+	ranges := [][2]float64{}
+	for i, arg := range args {
+		switch arg {
+		case "--non-negative":
+			if x < 0 {
+				return false
+			}
+		case "--positive":
+			if x <= 0 {
+				return false
+			}
+		case "--range", "-r":
+			if i+1 >= len(args) {
+				continue
+			}
+			bounds := strings.SplitN(args[i+1], "-", 2)
+			if len(bounds) != 2 {
+				continue
+			}
+			lo, errLo := strconv.ParseFloat(bounds[0], 64)
+			hi, errHi := strconv.ParseFloat(bounds[1], 64)
+			if errLo == nil && errHi == nil {
+				ranges = append(ranges, [2]float64{lo, hi})
+			}
+		}
+	}
+
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if x >= r[0] && x <= r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package interfacedef
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDefinition = `<?xml version="1.0"?>
+<interfaceDefinition>
+  <node name="interfaces">
+    <children>
+      <tagNode name="dummy">
+        <properties>
+          <help>Dummy interface</help>
+          <constraint>
+            <regex>dum[0-9]+</regex>
+          </constraint>
+          <constraintErrorMessage>Dummy interface must be named dumN</constraintErrorMessage>
+        </properties>
+        <children>
+          <leafNode name="address">
+            <properties>
+              <help>IP address</help>
+              <constraint>
+                <validator name="ipv4-host"/>
+                <validator name="ipv6-host"/>
+              </constraint>
+              <multi/>
+            </properties>
+          </leafNode>
+          <leafNode name="disable">
+            <properties>
+              <help>Administratively disable interface</help>
+              <valueless/>
+            </properties>
+          </leafNode>
+          <leafNode name="mtu">
+            <properties>
+              <help>Maximum Transmission Unit (MTU)</help>
+              <constraint>
+                <validator name="numeric" argument="--range 68-16000"/>
+              </constraint>
+            </properties>
+          </leafNode>
+          <leafNode name="description">
+            <properties>
+              <help>Description</help>
+            </properties>
+          </leafNode>
+          <leafNode name="lookahead">
+            <properties>
+              <help>Python syntax the local checks skip</help>
+              <constraint>
+                <regex>(?!foo).*</regex>
+              </constraint>
+            </properties>
+          </leafNode>
+        </children>
+      </tagNode>
+    </children>
+  </node>
+</interfaceDefinition>`
+
+func TestCheck(t *testing.T) {
+	root := New()
+	if err := root.Parse(strings.NewReader(testDefinition)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		err  string
+	}{
+		{"interfaces", ""},
+		{"interfaces dummy dum0", ""},
+		{"interfaces dummy dum0 address 10.0.0.1/24", ""},
+		{"interfaces dummy dum0 address 2001:db8::1/64", ""},
+		{"interfaces dummy dum0 disable", ""},
+		{"interfaces dummy dum0 mtu 1500", ""},
+		{"interfaces dummy dum0 description anything", ""},
+		{"interfaces dummy dum0 lookahead foo", ""},
+		{"system", "unknown configuration node 'system'"},
+		{"interfaces bogus", "unknown configuration node 'bogus' under 'interfaces'"},
+		{"interfaces dummy eth0", "invalid name 'eth0' for 'interfaces dummy': Dummy interface must be named dumN"},
+		{"interfaces dummy dum0 address 10.0.0.1", "invalid value '10.0.0.1' for 'interfaces dummy dum0 address'"},
+		{"interfaces dummy dum0 mtu 20", "invalid value '20' for 'interfaces dummy dum0 mtu': value does not match the node constraints"},
+		{"interfaces dummy dum0 mtu", "'interfaces dummy dum0 mtu' requires a value"},
+		{"interfaces dummy dum0 mtu 1500 1500", "'interfaces dummy dum0 mtu' takes a single value, got '1500 1500'"},
+		{"interfaces dummy dum0 disable yes", "'interfaces dummy dum0 disable' does not take a value"},
+	}
+	for _, tt := range tests {
+		_, err := root.Check(strings.Fields(tt.path))
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s: unexpected error: %s", tt.path, err)
+		case tt.err != "" && err == nil:
+			t.Errorf("%s: expected error %q", tt.path, tt.err)
+		case tt.err != "" && !strings.HasPrefix(err.Error(), tt.err):
+			t.Errorf("%s: got error %q, want %q", tt.path, err, tt.err)
+		}
+	}
+}
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		validator, argument, value string
+		valid                      bool
+	}{
+		{"numeric", "", "42", true},
+		{"numeric", "", "-42", true},
+		{"numeric", "", "4.2", false},
+		{"numeric", "", "forty", false},
+		{"numeric", "--float", "4.2", true},
+		{"numeric", "--non-negative", "0", true},
+		{"numeric", "--non-negative", "-1", false},
+		{"numeric", "--positive", "0", false},
+		{"numeric", "--positive", "1", true},
+		{"numeric", "--range 1-10", "1", true},
+		{"numeric", "--range 1-10", "10", true},
+		{"numeric", "--range 1-10", "11", false},
+		{"numeric", "--range 1-10 --range 20-30", "25", true},
+		{"numeric", "--range 1-10 --range 20-30", "15", false},
+		{"numeric", "--allow-range --range 1-100", "10-20", true},
+		{"numeric", "--allow-range --range 1-100", "10-200", false},
+		{"numeric", "--range 1-100", "10-20", false},
+
+		{"ipv4-address", "", "192.0.2.1", true},
+		{"ipv4-address", "", "2001:db8::1", false},
+		{"ipv4-address", "", "192.0.2.256", false},
+		{"ipv6-address", "", "2001:db8::1", true},
+		{"ipv6-address", "", "192.0.2.1", false},
+		{"ip-address", "", "192.0.2.1", true},
+		{"ip-address", "", "2001:db8::1", true},
+		{"ip-address", "", "host", false},
+
+		{"ipv4-prefix", "", "192.0.2.0/24", true},
+		{"ipv4-prefix", "", "192.0.2.1/24", false},
+		{"ipv4-prefix", "", "2001:db8::/32", false},
+		{"ipv6-prefix", "", "2001:db8::/32", true},
+		{"ipv6-prefix", "", "2001:db8::1/32", false},
+		{"ip-prefix", "", "192.0.2.0/24", true},
+		{"ip-prefix", "", "2001:db8::/32", true},
+		{"ipv4-host", "", "192.0.2.1/24", true},
+		{"ipv4-host", "", "192.0.2.1", false},
+		{"ipv6-host", "", "2001:db8::1/64", true},
+		{"ipv6-host", "", "192.0.2.1/24", false},
+		{"ip-host", "", "2001:db8::1/64", true},
+
+		{"mac-address", "", "00:53:00:ab:CD:ef", true},
+		{"mac-address", "", "00-53-00-ab-cd-ef", false},
+		{"mac-address", "", "00:53:00:ab:cd", false},
+
+		{"fqdn", "", "vyos.io", true},
+		{"fqdn", "", "router-1.example.com.", true},
+		{"fqdn", "", "localhost", true},
+		{"fqdn", "", "-bad.example.com", false},
+		{"fqdn", "", "bad_name.example.com", false},
+	}
+	for _, tt := range tests {
+		if valid := validators[tt.validator](tt.value, tt.argument); valid != tt.valid {
+			t.Errorf("%s %s: %q valid = %t, want %t", tt.validator, tt.argument, tt.value, valid, tt.valid)
+		}
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"flag"
 )
 
+// Generate typed resources from VyOS interface definitions, see tools/vyosgen
+//go:generate go run ./tools/vyosgen
+
 // Generate docs
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
@@ -27,4 +30,8 @@ func main() {
 	}
 
 	plugin.Serve(opts)
+
+	// Terraform stops the provider at the end of an apply, and kills it if
+	// it does not exit within a few seconds, so this save is best effort
+	vyos.SaveDeferred()
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+func generateGo(resources []*resource) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("// Code generated by vyosgen from VyOS interface definitions. DO NOT EDIT.\n\n")
+	b.WriteString("package vyos\n\n")
+	b.WriteString("import \"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema\"\n\n")
+
+	b.WriteString("var generatedResources = map[string]*schema.Resource{\n")
+	for _, r := range resources {
+		fmt.Fprintf(&b, "%q: %s(),\n", r.Name, r.Func)
+	}
+	b.WriteString("}\n")
+
+	for _, r := range resources {
+		fmt.Fprintf(&b, "\nfunc %s() *schema.Resource {\n", r.Func)
+		b.WriteString("r := &configResource{\n")
+		if r.Description != "" {
+			fmt.Fprintf(&b, "Description: %q,\n", r.Description)
+		}
+		fmt.Fprintf(&b, "Path: %q,\n", r.Path)
+		b.WriteString("Schema: ")
+		writeSchemaMap(&b, r.Attributes)
+		b.WriteString(",\nFields: ")
+		writeFields(&b, r.Fields)
+		b.WriteString(",\n}\n")
+		b.WriteString("return r.Resource()\n}\n")
+	}
+
+	return b.Bytes()
+}
+
+func writeSchemaMap(b *bytes.Buffer, attrs []*attribute) {
+	b.WriteString("map[string]*schema.Schema{\n")
+	for _, attr := range attrs {
+		fmt.Fprintf(b, "%q: {\n", attr.Name)
+		if attr.Description != "" {
+			fmt.Fprintf(b, "Description: %s,\n", strconv.Quote(attr.Description))
+		}
+
+		switch attr.Kind {
+		case kindString:
+			b.WriteString("Type: schema.TypeString,\n")
+		case kindInt:
+			b.WriteString("Type: schema.TypeInt,\n")
+		case kindBool:
+			b.WriteString("Type: schema.TypeBool,\n")
+		case kindMulti:
+			b.WriteString("Type: schema.TypeSet,\n")
+			b.WriteString("Elem: &schema.Schema{Type: schema.TypeString},\n")
+		case kindBlock:
+			b.WriteString("Type: schema.TypeList,\nMaxItems: 1,\n")
+			b.WriteString("Elem: &schema.Resource{Schema: ")
+			writeSchemaMap(b, attr.Children)
+			b.WriteString("},\n")
+		case kindTag:
+			b.WriteString("Type: schema.TypeSet,\n")
+			b.WriteString("Elem: &schema.Resource{Schema: ")
+			writeSchemaMap(b, attr.Children)
+			b.WriteString("},\n")
+		}
+
+		if attr.Required {
+			b.WriteString("Required: true,\n")
+		} else {
+			b.WriteString("Optional: true,\n")
+		}
+		if attr.ForceNew {
+			b.WriteString("ForceNew: true,\n")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}")
+}
+
+func writeFields(b *bytes.Buffer, attrs []*attribute) {
+	b.WriteString("[]configField{\n")
+	for _, attr := range attrs {
+		if attr.Node == "" {
+			// Tag node key
+			continue
+		}
+		fmt.Fprintf(b, "{Attr: %q, Node: %q", attr.Name, attr.Node)
+		if attr.Key != "" {
+			fmt.Fprintf(b, ", Key: %q", attr.Key)
+		}
+		if len(attr.Children) > 0 {
+			b.WriteString(", Fields: ")
+			writeFields(b, attr.Children)
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}")
+}
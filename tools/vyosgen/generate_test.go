@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/format"
+	"os"
+	"testing"
+
+	"github.com/foltik/terraform-provider-vyos/internal/interfacedef"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// TestGenerateGo renders the service ssh definition of VyOS 1.4 and
+// compares it with testdata/service_ssh.go.golden.
+func TestGenerateGo(t *testing.T) {
+	root, err := interfacedef.Load("testdata/service_ssh.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := newResource(root, selection{Name: "vyos_service_ssh", Path: "service ssh"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := format.Source(generateGo([]*resource{r}))
+	if err != nil {
+		t.Fatalf("generated code does not parse: %s", err)
+	}
+
+	const golden = "testdata/service_ssh.go.golden"
+	if *update {
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generated code differs from %s, rerun with -update if the change is intended:\n%s", golden, got)
+	}
+}
+
+func TestNewResourceErrors(t *testing.T) {
+	root, err := interfacedef.Load("testdata/service_ssh.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"service telnet", "service ssh loglevel"} {
+		if _, err := newResource(root, selection{Name: "vyos_test", Path: path}); err == nil {
+			t.Errorf("%s: expected an error", path)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Command vyosgen generates typed resources from VyOS interface-definition
+// XML files, so new VyOS releases can be covered by regenerating.
+//
+// Subtrees are selected in resources.json:
+//
+//	[
+//	  {"name": "vyos_service_ssh", "path": "service ssh"},
+//	  {"name": "vyos_interface_dummy", "path": "interfaces dummy"}
+//	]
+//
+// Each one becomes a configResource in vyos/resources_generated.go, which
+// tfplugindocs documents like the other resources. If the path ends at a
+// tag node, the resource manages a single instance named by its `name`
+// attribute.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/foltik/terraform-provider-vyos/internal/interfacedef"
+)
+
+type selection struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	Description string `json:"description,omitempty"`
+}
+
+func main() {
+	definitions := flag.String("definitions", os.Getenv("VYOS_INTERFACE_DEFINITIONS"), "VyOS interface-definition XML file or directory")
+	config := flag.String("config", "tools/vyosgen/resources.json", "resource selection")
+	out := flag.String("out", "vyos/resources_generated.go", "generated Go file")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("vyosgen: ")
+
+	if *definitions == "" {
+		log.Print("no interface definitions given, set VYOS_INTERFACE_DEFINITIONS to regenerate typed resources")
+		return
+	}
+
+	root, err := interfacedef.Load(*definitions)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(*config)
+	if err != nil {
+		log.Fatal(err)
+	}
+	selections := []selection{}
+	if err := json.Unmarshal(raw, &selections); err != nil {
+		log.Fatalf("%s: %s", *config, err)
+	}
+	sort.Slice(selections, func(i, j int) bool { return selections[i].Name < selections[j].Name })
+
+	resources := []*resource{}
+	for _, sel := range selections {
+		r, err := newResource(root, sel)
+		if err != nil {
+			log.Fatalf("%s: %s", sel.Name, err)
+		}
+		resources = append(resources, r)
+	}
+
+	src, err := format.Source(generateGo(resources))
+	if err != nil {
+		log.Fatalf("formatting generated code: %s", err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type attrKind int
+
+const (
+	kindString attrKind = iota
+	kindInt
+	kindBool
+	kindMulti
+	kindBlock
+	kindTag
+)
+
+// attribute is a schema attribute derived from a definition node.
+type attribute struct {
+	Name        string
+	Node        string
+	Description string
+	Kind        attrKind
+	Required    bool
+	ForceNew    bool
+
+	// For kindTag, the child attribute holding the tag node name
+	Key      string
+	Children []*attribute
+}
+
+type resource struct {
+	Name        string
+	Func        string
+	Description string
+	Path        string
+	Attributes  []*attribute
+	Fields      []*attribute
+}
+
+func newResource(root *interfacedef.Node, sel selection) (*resource, error) {
+	node := root
+	for _, word := range strings.Fields(sel.Path) {
+		child, ok := node.Children[word]
+		if !ok {
+			return nil, fmt.Errorf("no node '%s' in '%s'", word, sel.Path)
+		}
+		node = child
+	}
+
+	r := &resource{
+		Name:        sel.Name,
+		Func:        "resource" + camelCase(strings.TrimPrefix(sel.Name, "vyos_")),
+		Description: sel.Description,
+		Path:        sel.Path,
+	}
+	if r.Description == "" {
+		r.Description = node.Help
+	}
+
+	switch node.Kind {
+	case interfacedef.KindLeafNode:
+		return nil, fmt.Errorf("'%s' is a leaf node, use vyos_config instead", sel.Path)
+	case interfacedef.KindTagNode:
+		key := keyName(node)
+		r.Path += " {" + key + "}"
+		r.Attributes = append(r.Attributes, &attribute{
+			Name:        key,
+			Description: "Name of the " + node.Name + ".",
+			Kind:        kindString,
+			Required:    true,
+			ForceNew:    true,
+		})
+	}
+
+	r.Fields = childAttributes(node)
+	r.Attributes = append(r.Attributes, r.Fields...)
+	return r, nil
+}
+
+func childAttributes(node *interfacedef.Node) []*attribute {
+	attrs := []*attribute{}
+	for _, name := range node.ChildNames() {
+		attrs = append(attrs, newAttribute(node.Children[name]))
+	}
+	return attrs
+}
+
+func newAttribute(node *interfacedef.Node) *attribute {
+	attr := &attribute{
+		Name:        attributeName(node.Name),
+		Node:        node.Name,
+		Description: node.Help,
+	}
+
+	switch node.Kind {
+	case interfacedef.KindLeafNode:
+		switch {
+		case node.Valueless:
+			attr.Kind = kindBool
+		case node.Multi:
+			attr.Kind = kindMulti
+		case isInteger(node.Constraint):
+			attr.Kind = kindInt
+		default:
+			attr.Kind = kindString
+		}
+	case interfacedef.KindTagNode:
+		attr.Kind = kindTag
+		attr.Key = keyName(node)
+		attr.Children = append([]*attribute{{
+			Name:        attr.Key,
+			Description: "Name of the " + node.Name + ".",
+			Kind:        kindString,
+			Required:    true,
+		}}, childAttributes(node)...)
+	default:
+		attr.Kind = kindBlock
+		attr.Children = childAttributes(node)
+	}
+	return attr
+}
+
+// isInteger reports whether the constraint only accepts plain integers.
+func isInteger(c *interfacedef.Constraint) bool {
+	if c == nil || len(c.Regexes) > 0 || len(c.Validators) != 1 {
+		return false
+	}
+	v := c.Validators[0]
+	return v.Name == "numeric" && !strings.Contains(v.Argument, "--allow-range") && !strings.Contains(v.Argument, "--float")
+}
+
+// keyName picks the attribute holding a tag node name, avoiding children.
+func keyName(node *interfacedef.Node) string {
+	if _, ok := node.Children["name"]; ok {
+		return "tag"
+	}
+	return "name"
+}
+
+var reservedNames = map[string]bool{
+	"id": true, "count": true, "depends_on": true, "for_each": true, "lifecycle": true,
+	"provider": true, "provisioner": true, "connection": true, "timeouts": true,
+}
+
+func attributeName(node string) string {
+	name := strings.ReplaceAll(node, "-", "_")
+	if reservedNames[name] {
+		name += "_value"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "x" + name
+	}
+	return name
+}
+
+func camelCase(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' })
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,98 @@
+package vyos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// apiClient calls the VyOS HTTP API endpoints which the client library
+// does not cover, such as op-mode /show.
+type apiClient struct {
+	http    *http.Client
+	url     string
+	key     string
+	limiter *requestLimiter
+}
+
+type apiResponse struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Error   *string         `json:"error"`
+}
+
+// Request posts payload to endpoint and decodes the data member of the
+// response into out.
+func (a *apiClient) Request(ctx context.Context, endpoint string, payload any, out any) error {
+	return a.limiter.do(ctx, func() error {
+		return a.request(ctx, endpoint, payload, out)
+	})
+}
+
+func (a *apiClient) request(ctx context.Context, endpoint string, payload any, out any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("key", a.key)
+	form.Set("data", string(data))
+
+	endpointURL := strings.TrimSuffix(a.url, "/") + "/" + endpoint
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("/%s: %s: %w", endpoint, resp.Status, err)
+	}
+	if !body.Success {
+		msg := resp.Status
+		if body.Error != nil {
+			msg = *body.Error
+		}
+		return fmt.Errorf("/%s: %s", endpoint, msg)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(body.Data, out)
+}
+
+// Show runs an op-mode show command, e.g. "interfaces", and returns its output.
+func (a *apiClient) Show(ctx context.Context, command string) (string, error) {
+	var output string
+	err := a.Request(ctx, "show", map[string]any{
+		"op":   "show",
+		"path": strings.Fields(command),
+	}, &output)
+	return output, err
+}
+
+// configOp is a single operation of a /configure request.
+type configOp struct {
+	Op   string   `json:"op"`
+	Path []string `json:"path"`
+}
+
+// Configure applies ops in a single commit, which the client library can
+// not do for a mix of sets and deletes.
+func (a *apiClient) Configure(ctx context.Context, ops []configOp) error {
+	return a.limiter.write(ctx, func() error {
+		return a.request(ctx, "configure", ops, nil)
+	})
+}
@@ -0,0 +1,1119 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configResource describes a typed resource stored as a config subtree.
+// It implements CRUD, import and plan-time validation by mapping the
+// resource attributes onto the subtree using Fields.
+type configResource struct {
+	Description string
+
+	// Config path of the resource. Words of the form {attr} are replaced
+	// with the value of that attribute, which must be ForceNew and either
+	// Required or have a Default.
+	// The rendered path is used as the resource ID.
+	Path string
+
+	// VRF adds a vrf attribute, which moves Path below "vrf name <vrf>".
+	VRF bool
+
+	// InterfaceVRF adds a vrf attribute for interfaces, which is set at
+	// the vrf node below Path instead.
+	InterfaceVRF bool
+
+	Schema map[string]*schema.Schema
+	Fields []configField
+
+	// Shared is set for paths which also hold config of other resources,
+	// such as "system". The attributes set in the configuration are recorded
+	// in managed_attributes. Create adopts the existing config of those, and
+	// delete only removes theirs. The attributes should be Optional and
+	// Computed, so unset ones stay unmanaged.
+	Shared bool
+
+	// Atomic makes each create, update and delete a single commit, for
+	// config which is only valid when changed together, such as moving
+	// interfaces in and out of a bond.
+	Atomic bool
+
+	// AtomicOps returns operations on config Fields do not describe, often
+	// outside Path, to include in the commit of an Atomic resource, in the
+	// router syntax, given the old and new attribute values. Both are always
+	// set, and return nil for all attributes on create and delete
+	// respectively.
+	AtomicOps func(ctx context.Context, p *ProviderClass, path string, old, new func(string) interface{}) ([]configOp, error)
+
+	// AtomicRead returns the values of the attributes AtomicOps applies,
+	// given the config subtree at Path in the latest syntax.
+	AtomicRead func(ctx context.Context, p *ProviderClass, path string, tree map[string]interface{}) (map[string]interface{}, error)
+
+	// Expand and Flatten handle attributes Fields can not describe. Expand
+	// returns the commands setting them, and Flatten their values read from
+	// the config subtree, given get for their current values.
+	Expand  func(get func(string) interface{}) []configCommand
+	Flatten func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{}
+
+	// BeforeDelete can refuse to delete the resource.
+	BeforeDelete func(p *ProviderClass, d *schema.ResourceData) error
+
+	// CustomizeDiff validates the plan beyond what the schema can express.
+	CustomizeDiff schema.CustomizeDiffFunc
+}
+
+// configField maps a Terraform attribute onto a config node below the
+// resource path. How values are rendered depends on the attribute type:
+//
+//   - String, Int and Float attributes are leaf nodes with a value
+//   - Bool attributes are valueless leaf nodes, present when true
+//   - Lists and sets of primitives are multi-value leaf nodes, or tag
+//     nodes without children
+//   - Blocks are nodes, with Fields describing their children. If Key is
+//     set the node is a tag node, and each element is the instance named
+//     by its Key attribute.
+//
+// Ref is the path of the config a value refers to, e.g. "vpn ipsec
+// ike-group", which must exist when the resource is created or updated.
+// Terraform does not store WriteOnly attributes, so they are only sent on
+// create, and on update when the attribute <attr>_version changes.
+//
+// The SDK stores unset numbers as 0, so a zero number is only sent when
+// it is set in the configuration. Removing it from the configuration does
+// not remove it from the router, as the SDK sees no change.
+type configField struct {
+	Attr   string
+	Node   string
+	Key    string
+	Ref    string
+	Fields []configField
+}
+
+// configCommand is a single "set" below a resource path.
+type configCommand struct {
+	path  []string
+	value string
+	multi bool
+
+	// owner is the length of the path prefix naming the field node the
+	// command belongs to, deletes never remove a shorter prefix.
+	owner int
+
+	// ref is the Ref of the field.
+	ref string
+}
+
+func (r *configResource) Resource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"id": {
+			Description: "The resource ID, same as the config path",
+			Type:        schema.TypeString,
+			Computed:    true,
+		},
+		"device": deviceSchema(),
+	}
+	if r.VRF {
+		s["vrf"] = &schema.Schema{
+			Description: "VRF the config belongs to, the default VRF if not set.",
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+		}
+	}
+	if r.InterfaceVRF {
+		r.Schema["vrf"] = &schema.Schema{
+			Description: "VRF the interface belongs to.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+		r.Fields = append([]configField{{Attr: "vrf", Node: "vrf", Ref: "vrf name"}}, r.Fields...)
+	}
+	if r.Shared {
+		s["managed_attributes"] = &schema.Schema{
+			Description: "Attributes set in the configuration. Destroying the resource only removes their config.",
+			Type:        schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Computed: true,
+		}
+	}
+	for attr, attrSchema := range r.Schema {
+		s[attr] = attrSchema
+	}
+
+	return &schema.Resource{
+		Description:   r.Description,
+		CreateContext: r.create,
+		ReadContext:   r.read,
+		UpdateContext: r.update,
+		DeleteContext: r.delete,
+		CustomizeDiff: r.customizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: importDevice(r.importState),
+		},
+		Schema: s,
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(10 * time.Minute),
+			Read:    schema.DefaultTimeout(10 * time.Minute),
+			Update:  schema.DefaultTimeout(10 * time.Minute),
+			Delete:  schema.DefaultTimeout(10 * time.Minute),
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func (r *configResource) create(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	c := *p.client
+	path := r.renderPath(d.Get)
+
+	get := r.configured(d.GetRawConfig(), r.writeOnly(d, func(string) bool { return true }))
+	routerPath, commands, err := p.routerCommands(path, r.expand(get))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Check if config already exists
+	existing, err := p.ShowCached(ctx, routerPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := r.checkReferences(ctx, p, r.vrfReference(d.Get)); diags.HasError() {
+		return diags
+	}
+	if r.Shared {
+		return r.adopt(ctx, d, p, path, routerPath, existing, r.managed(d, get))
+	}
+	if diags := r.checkReferences(ctx, p, commands); diags.HasError() {
+		return diags
+	}
+	if existing != nil {
+		return diag.Errorf("Configuration '%s' already exists, try a resource import instead.", routerPath)
+	}
+
+	if r.Atomic {
+		var ops []configOp
+		ops, err = r.atomicOps(ctx, p, path, noValues, get)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(commands) == 0 {
+			ops = append([]configOp{{Op: "set", Path: strings.Fields(routerPath)}}, ops...)
+		}
+		err = p.api.Configure(ctx, append(configOps(routerPath, commands, nil), ops...))
+	} else if len(commands) == 0 {
+		err = c.Config.Set(ctx, routerPath, "")
+	} else {
+		err = c.Config.Set(ctx, routerPath, configCommandMap(commands))
+	}
+	if err != nil {
+		return r.errorDiags(p, err, "set", path, routerPath)
+	}
+
+	d.SetId(path)
+	return p.conditionalSave(ctx)
+}
+
+func (r *configResource) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path := d.Id()
+
+	keys, ok := r.parsePath(path)
+	if !ok {
+		return diag.Errorf("Resource ID '%s' does not match '%s'.", path, r.Path)
+	}
+	if p, err = p.existingVersion(ctx, path); err != nil {
+		return diag.FromErr(err)
+	}
+
+	routerPath, _, err := p.routerCommands(path, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tree, err := p.ShowCached(ctx, routerPath)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if tree == nil {
+		// Removed outside of terraform
+		d.SetId("")
+		return diag.Diagnostics{}
+	}
+
+	latest := p.latestConfig(path, routerPath, tree)
+	values := r.flatten(latest, d.Get)
+	if r.AtomicRead != nil {
+		atomic, err := r.AtomicRead(ctx, p, path, latest)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for attr, value := range atomic {
+			values[attr] = value
+		}
+	}
+	for attr, value := range keys {
+		values[attr] = value
+	}
+	for attr, value := range values {
+		if s := r.Schema[attr]; s != nil && s.WriteOnly {
+			continue
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diag.Diagnostics{}
+}
+
+func (r *configResource) update(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path := d.Id()
+
+	// The old configuration is unknown, so old zero numbers count as unset
+	old := r.expand(r.configured(cty.NilVal, func(attr string) interface{} {
+		o, _ := d.GetChange(attr)
+		return o
+	}))
+	new := r.expand(r.configured(d.GetRawConfig(), r.writeOnly(d, func(attr string) bool {
+		return d.HasChange(attr + "_version")
+	})))
+	set, del := diffConfig(old, new)
+
+	ops, err := r.atomicOps(ctx, p, path, func(attr string) interface{} {
+		o, _ := d.GetChange(attr)
+		return o
+	}, d.Get)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if diags := r.apply(ctx, p, path, set, del, ops); diags.HasError() {
+		return diags
+	}
+	return p.conditionalSave(ctx)
+}
+
+// adopt creates a Shared resource, changing only the managed attributes,
+// which get returns.
+func (r *configResource) adopt(ctx context.Context, d *schema.ResourceData, p *ProviderClass, path, routerPath string, existing any, get func(string) interface{}) diag.Diagnostics {
+	current := r.flatten(p.latestConfig(path, routerPath, existing), d.Get)
+
+	var set, del []configCommand
+	for _, field := range r.Fields {
+		if get(field.Attr) == nil {
+			continue
+		}
+		new := r.expandField(field, get)
+		old := r.expandField(field, func(attr string) interface{} { return current[attr] })
+		fieldSet, fieldDel := diffConfig(old, new)
+		set, del = append(set, fieldSet...), append(del, fieldDel...)
+	}
+	if r.Expand != nil {
+		set = append(set, r.Expand(get)...)
+	}
+
+	if diags := r.apply(ctx, p, path, set, del, nil); diags.HasError() {
+		return diags
+	}
+	d.SetId(path)
+	return p.conditionalSave(ctx)
+}
+
+// apply sets and deletes commands below path, translated to the router
+// syntax. Atomic resources apply them in a single commit with ops.
+func (r *configResource) apply(ctx context.Context, p *ProviderClass, path string, set, del []configCommand, ops []configOp) diag.Diagnostics {
+	c := *p.client
+
+	if diags := r.checkReferences(ctx, p, set); diags.HasError() {
+		return diags
+	}
+
+	routerPath, set, err := p.routerCommands(path, set)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_, del, err = p.routerCommands(path, del)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if r.Atomic {
+		ops = append(configOps(routerPath, set, del), ops...)
+		if len(ops) == 0 {
+			return diag.Diagnostics{}
+		}
+		if err := p.api.Configure(ctx, ops); err != nil {
+			return r.errorDiags(p, err, "change", path, routerPath)
+		}
+		return diag.Diagnostics{}
+	}
+
+	// Set before deleting so the intermediate config stays valid, see
+	// resourceConfigBlockTreeUpdate.
+	if len(set) > 0 {
+		if err := c.Config.Set(ctx, routerPath, configCommandMap(set)); err != nil {
+			return r.errorDiags(p, err, "set", path, routerPath)
+		}
+	}
+	if len(del) > 0 {
+		if err := c.Config.Delete(ctx, routerPath, configCommandMap(del)); err != nil {
+			return r.errorDiags(p, err, "delete", path, routerPath)
+		}
+	}
+	return diag.Diagnostics{}
+}
+
+func (r *configResource) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if p, err = p.existingVersion(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	c := *p.client
+
+	if r.BeforeDelete != nil {
+		if err := r.BeforeDelete(p, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if r.Shared {
+		_, del := diffConfig(r.expand(r.managed(d, r.configured(cty.NilVal, d.Get))), nil)
+		if diags := r.apply(ctx, p, d.Id(), nil, del, nil); diags.HasError() {
+			return diags
+		}
+		return p.conditionalSave(ctx)
+	}
+
+	routerPath, _, err := p.routerCommands(d.Id(), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if r.Atomic {
+		var ops []configOp
+		ops, err = r.atomicOps(ctx, p, d.Id(), d.Get, noValues)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = p.api.Configure(ctx, append([]configOp{{Op: "delete", Path: strings.Fields(routerPath)}}, ops...))
+	} else {
+		err = c.Config.Delete(ctx, routerPath)
+	}
+	if err != nil {
+		return r.errorDiags(p, err, "delete", d.Id(), routerPath)
+	}
+
+	return p.conditionalSave(ctx)
+}
+
+func (r *configResource) atomicOps(ctx context.Context, p *ProviderClass, path string, old, new func(string) interface{}) ([]configOp, error) {
+	if r.AtomicOps == nil {
+		return nil, nil
+	}
+	return r.AtomicOps(ctx, p, path, old, new)
+}
+
+// noValues returns nil for every attribute.
+func noValues(string) interface{} {
+	return nil
+}
+
+// configOps converts commands below routerPath to the operations of a
+// commit, sets first.
+func configOps(routerPath string, set, del []configCommand) []configOp {
+	ops := []configOp{}
+	for _, op := range []struct {
+		name     string
+		commands []configCommand
+	}{{"set", set}, {"delete", del}} {
+		for _, cmd := range op.commands {
+			path := append(strings.Fields(routerPath), cmd.path...)
+			if cmd.value != "" {
+				path = append(path, cmd.value)
+			}
+			ops = append(ops, configOp{Op: op.name, Path: path})
+		}
+	}
+	return ops
+}
+
+// checkReferences fails if a command refers to config which does not exist.
+// It reads the router instead of the cache, since the config may have been
+// created earlier in the apply.
+func (r *configResource) checkReferences(ctx context.Context, p *ProviderClass, commands []configCommand) diag.Diagnostics {
+	c := *p.client
+
+	for _, cmd := range commands {
+		if cmd.ref == "" {
+			continue
+		}
+		ref := append(strings.Fields(cmd.ref), cmd.value)
+		routerRef, err := p.routerPath(ref)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		value, err := c.Config.Show(ctx, strings.Join(routerRef, " "))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if value == nil {
+			return diag.Errorf("'%s' refers to '%s', which does not exist.", strings.Join(cmd.path, " "), strings.Join(ref, " "))
+		}
+	}
+	return diag.Diagnostics{}
+}
+
+// errorDiags converts the error of a request to `action` the config at
+// routerPath to diagnostics, pointing at the attribute of the node VyOS
+// reported the error for.
+func (r *configResource) errorDiags(p *ProviderClass, err error, action, path, routerPath string) diag.Diagnostics {
+	var attr cty.Path
+	base := strings.Fields(path)
+	if failing := p.latestPath(parseConfigError(err).Path); len(failing) > len(base) {
+		if _, ok := matchPathPrefix(base, failing); ok {
+			for _, field := range r.Fields {
+				if field.Node == failing[len(base)] {
+					attr = cty.GetAttrPath(field.Attr)
+				}
+			}
+		}
+	}
+	return configErrorDiags(err, action, routerPath, attr)
+}
+
+// importState accepts either the full config path or just the values of
+// the path attributes, separated by spaces.
+func (r *configResource) importState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	id := d.Id()
+	if _, ok := r.parsePath(id); ok {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	values := strings.Fields(id)
+	attrs := r.pathAttrs()
+	if len(values) != len(attrs) {
+		return nil, fmt.Errorf("expected '%s' or the values of %s, got '%s'", r.Path, strings.Join(attrs, ", "), id)
+	}
+	d.SetId(r.renderPath(func(attr string) interface{} {
+		for i := range attrs {
+			if attrs[i] == attr {
+				return values[i]
+			}
+		}
+		return ""
+	}))
+	return []*schema.ResourceData{d}, nil
+}
+
+func (r *configResource) customizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if r.CustomizeDiff != nil {
+		if err := r.CustomizeDiff(ctx, d, m); err != nil {
+			return err
+		}
+	}
+
+	if r.Shared {
+		managed := r.configuredAttrs(d.GetRawConfig())
+		if !d.Get("managed_attributes").(*schema.Set).Equal(managed) {
+			if err := d.SetNew("managed_attributes", managed); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The device is only known during apply when it is computed
+	if !d.NewValueKnown("device") {
+		return nil
+	}
+	for _, attr := range r.pathAttrs() {
+		if !d.NewValueKnown(attr) {
+			return nil
+		}
+	}
+	if r.VRF && !d.NewValueKnown("vrf") {
+		return nil
+	}
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return err
+	}
+
+	if p.versionErr != nil && d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+		// Nothing to change, so refreshing needs no version
+		return nil
+	}
+
+	// Fails for paths the router version does not support
+	get := r.configured(d.GetRawConfig(), r.writeOnly(d, func(string) bool { return true }))
+	path, commands, err := p.routerCommands(r.renderPath(d.Get), r.expand(get))
+	if err != nil {
+		return err
+	}
+
+	if err := p.checkConfig(path); err != nil {
+		return err
+	}
+	for _, cmd := range commands {
+		if err := p.checkConfig(path+" "+strings.Join(cmd.path, " "), cmd.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *configResource) pathAttrs() []string {
+	attrs := []string{}
+	for _, word := range strings.Fields(r.Path) {
+		if strings.HasPrefix(word, "{") && strings.HasSuffix(word, "}") {
+			attrs = append(attrs, word[1:len(word)-1])
+		}
+	}
+	return attrs
+}
+
+func (r *configResource) renderPath(get func(string) interface{}) string {
+	words := strings.Fields(r.Path)
+	for i, word := range words {
+		if strings.HasPrefix(word, "{") && strings.HasSuffix(word, "}") {
+			words[i] = fmt.Sprint(get(word[1 : len(word)-1]))
+		}
+	}
+	if vrf := r.vrf(get); vrf != "" {
+		words = append([]string{"vrf", "name", vrf}, words...)
+	}
+	return strings.Join(words, " ")
+}
+
+// parsePath extracts the path attributes from a rendered path.
+func (r *configResource) parsePath(path string) (map[string]interface{}, bool) {
+	template, words := strings.Fields(r.Path), strings.Fields(path)
+
+	values := map[string]interface{}{}
+	if r.VRF {
+		values["vrf"] = ""
+		if len(words) > 3 && words[0] == "vrf" && words[1] == "name" {
+			values["vrf"], words = words[2], words[3:]
+		}
+	}
+	if len(template) != len(words) {
+		return nil, false
+	}
+
+	for i, word := range template {
+		if strings.HasPrefix(word, "{") && strings.HasSuffix(word, "}") {
+			attr := word[1 : len(word)-1]
+			value, ok := configScalar(r.Schema[attr], words[i])
+			if !ok {
+				return nil, false
+			}
+			values[attr] = value
+		} else if word != words[i] {
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+func (r *configResource) vrf(get func(string) interface{}) string {
+	if !r.VRF {
+		return ""
+	}
+	return configString(get("vrf"))
+}
+
+// vrfReference is a command referring to the VRF, which must exist.
+func (r *configResource) vrfReference(get func(string) interface{}) []configCommand {
+	if vrf := r.vrf(get); vrf != "" {
+		return []configCommand{{path: []string{"vrf"}, value: vrf, ref: "vrf name"}}
+	}
+	return nil
+}
+
+func (r *configResource) expand(get func(string) interface{}) []configCommand {
+	commands := []configCommand{}
+	for _, field := range r.Fields {
+		commands = append(commands, r.expandField(field, get)...)
+	}
+	if r.Expand != nil {
+		commands = append(commands, r.Expand(get)...)
+	}
+	return commands
+}
+
+func (r *configResource) expandField(field configField, get func(string) interface{}) []configCommand {
+	commands := expandField(field, r.Schema[field.Attr], get(field.Attr))
+	for i := range commands {
+		commands[i].owner = len(strings.Fields(field.Node))
+	}
+	return commands
+}
+
+func (r *configResource) flatten(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+	values := flattenConfig(r.Fields, r.Schema, tree)
+	if r.Flatten != nil {
+		for attr, value := range r.Flatten(tree, get) {
+			values[attr] = value
+		}
+	}
+	return values
+}
+
+// configuredAttrs returns the attributes set in the raw configuration,
+// which a Shared resource manages. Unknown values count as set.
+func (r *configResource) configuredAttrs(raw cty.Value) *schema.Set {
+	attrs := schema.NewSet(schema.HashString, nil)
+	if raw.IsNull() || !raw.IsKnown() {
+		return attrs
+	}
+	for attr := range r.Schema {
+		if raw.Type().HasAttribute(attr) && !raw.GetAttr(attr).IsNull() {
+			attrs.Add(attr)
+		}
+	}
+	for _, attr := range r.pathAttrs() {
+		attrs.Remove(attr)
+	}
+	return attrs
+}
+
+// managed wraps get to return nil for the attributes a Shared resource
+// does not manage.
+func (r *configResource) managed(d interface{ Get(string) interface{} }, get func(string) interface{}) func(string) interface{} {
+	managed, _ := d.Get("managed_attributes").(*schema.Set)
+	return func(attr string) interface{} {
+		if managed == nil || !managed.Contains(attr) {
+			return nil
+		}
+		return get(attr)
+	}
+}
+
+// configured wraps get to return nil for zero numbers which are not set in
+// raw, the configuration, including those in blocks. Without raw all zero
+// numbers count as unset.
+func (r *configResource) configured(raw cty.Value, get func(string) interface{}) func(string) interface{} {
+	return func(attr string) interface{} {
+		field := configField{Attr: attr}
+		for _, f := range r.Fields {
+			if f.Attr == attr {
+				field = f
+			}
+		}
+		return configuredZeros(field, r.Schema[attr], get(attr), rawAttr(raw, attr))
+	}
+}
+
+// writeOnly wraps d.Get to read WriteOnly attributes from the config,
+// as far as send allows.
+func (r *configResource) writeOnly(d interface {
+	Get(string) interface{}
+	GetRawConfigAt(cty.Path) (cty.Value, diag.Diagnostics)
+}, send func(attr string) bool) func(string) interface{} {
+	return func(attr string) interface{} {
+		if s := r.Schema[attr]; s == nil || !s.WriteOnly {
+			return d.Get(attr)
+		}
+		if !send(attr) {
+			return nil
+		}
+		value, diags := d.GetRawConfigAt(cty.GetAttrPath(attr))
+		if diags.HasError() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+			return nil
+		}
+		return value.AsString()
+	}
+}
+
+// expandConfig renders attribute values into the commands that set them.
+func expandConfig(fields []configField, s map[string]*schema.Schema, get func(string) interface{}) []configCommand {
+	commands := []configCommand{}
+	for _, field := range fields {
+		commands = append(commands, expandField(field, s[field.Attr], get(field.Attr))...)
+	}
+	return commands
+}
+
+// configuredZeros returns value, replacing zero numbers with nil where raw
+// is null. Blocks of lists are matched with raw by index, and of sets by
+// the Key of field, which is kept as is.
+func configuredZeros(field configField, s *schema.Schema, value interface{}, raw cty.Value) interface{} {
+	if s == nil {
+		return value
+	}
+
+	switch s.Type {
+	case schema.TypeInt, schema.TypeFloat:
+		if configString(value) == "0" && (raw.IsNull() || !raw.IsKnown()) {
+			return nil
+		}
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Resource)
+		if !ok {
+			return value
+		}
+		items := []interface{}{}
+		for i, item := range configItems(value) {
+			block, _ := item.(map[string]interface{})
+			rawBlock := rawElement(raw, i, field.Key, block)
+			values := map[string]interface{}{}
+			for attr, v := range block {
+				if attr == field.Key {
+					values[attr] = v
+					continue
+				}
+				child := configField{Attr: attr}
+				for _, f := range field.Fields {
+					if f.Attr == attr {
+						child = f
+					}
+				}
+				values[attr] = configuredZeros(child, elem.Schema[attr], v, rawAttr(rawBlock, attr))
+			}
+			items = append(items, values)
+		}
+		return items
+	}
+	return value
+}
+
+// rawAttr returns the attribute of the object raw, or cty.NilVal if raw is
+// not a known object.
+func rawAttr(raw cty.Value, attr string) cty.Value {
+	if raw.IsNull() || !raw.IsKnown() || !raw.Type().IsObjectType() || !raw.Type().HasAttribute(attr) {
+		return cty.NilVal
+	}
+	return raw.GetAttr(attr)
+}
+
+// rawElement returns the element of the list or set raw holding block,
+// which is the i-th element of a list, or the element with the same key
+// in a set. It returns cty.NilVal if there is none.
+func rawElement(raw cty.Value, i int, key string, block map[string]interface{}) cty.Value {
+	if raw.IsNull() || !raw.IsKnown() {
+		return cty.NilVal
+	}
+	switch {
+	case raw.Type().IsListType():
+		if i < raw.LengthInt() {
+			return raw.Index(cty.NumberIntVal(int64(i)))
+		}
+	case raw.Type().IsSetType() && key != "":
+		for it := raw.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			if name := rawAttr(elem, key); rawString(name) != "" && rawString(name) == configString(block[key]) {
+				return elem
+			}
+		}
+	}
+	return cty.NilVal
+}
+
+// rawString renders a known string or number like configString.
+func rawString(v cty.Value) string {
+	if v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+	switch v.Type() {
+	case cty.String:
+		return v.AsString()
+	case cty.Number:
+		return v.AsBigFloat().Text('f', -1)
+	}
+	return ""
+}
+
+func expandField(field configField, s *schema.Schema, value interface{}) []configCommand {
+	node := strings.Fields(field.Node)
+
+	switch s.Type {
+	case schema.TypeBool:
+		if v, _ := value.(bool); v {
+			return []configCommand{{path: node}}
+		}
+	case schema.TypeString, schema.TypeInt, schema.TypeFloat:
+		if v := configString(value); v != "" {
+			return []configCommand{{path: node, value: v, ref: field.Ref}}
+		}
+	case schema.TypeList, schema.TypeSet:
+		commands := []configCommand{}
+		for _, item := range configItems(value) {
+			switch elem := s.Elem.(type) {
+			case *schema.Schema:
+				if v := configString(item); v != "" {
+					commands = append(commands, configCommand{path: node, value: v, multi: true, ref: field.Ref})
+				}
+			case *schema.Resource:
+				block, _ := item.(map[string]interface{})
+				base := node
+				if field.Key != "" {
+					base = append(node[:len(node):len(node)], configString(block[field.Key]))
+				}
+				children := expandConfig(field.Fields, elem.Schema, func(attr string) interface{} {
+					return block[attr]
+				})
+				if len(children) == 0 {
+					commands = append(commands, configCommand{path: base})
+				}
+				for _, child := range children {
+					child.path = append(base[:len(base):len(base)], child.path...)
+					commands = append(commands, child)
+				}
+			}
+		}
+		return commands
+	}
+	return nil
+}
+
+// flattenConfig reads attribute values from a config subtree.
+func flattenConfig(fields []configField, s map[string]*schema.Schema, tree map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, field := range fields {
+		node, ok := lookupConfig(tree, field.Node)
+		values[field.Attr] = flattenField(field, s[field.Attr], node, ok)
+	}
+	return values
+}
+
+func flattenField(field configField, s *schema.Schema, node interface{}, ok bool) interface{} {
+	switch s.Type {
+	case schema.TypeBool:
+		return ok
+	case schema.TypeString, schema.TypeInt, schema.TypeFloat:
+		if !ok {
+			return nil
+		}
+		values := configStrings(node)
+		if len(values) == 0 {
+			return nil
+		}
+		value, _ := configScalar(s, values[0])
+		return value
+	case schema.TypeList, schema.TypeSet:
+		items := []interface{}{}
+		if !ok {
+			return items
+		}
+		switch elem := s.Elem.(type) {
+		case *schema.Schema:
+			for _, v := range configStrings(node) {
+				if value, ok := configScalar(elem, v); ok {
+					items = append(items, value)
+				}
+			}
+		case *schema.Resource:
+			tree := configMap(node)
+			if field.Key == "" {
+				return append(items, flattenConfig(field.Fields, elem.Schema, tree))
+			}
+			for _, name := range sortedConfigKeys(tree) {
+				block := flattenConfig(field.Fields, elem.Schema, configMap(tree[name]))
+				block[field.Key], _ = configScalar(elem.Schema[field.Key], name)
+				items = append(items, block)
+			}
+		}
+		return items
+	}
+	return nil
+}
+
+// diffConfig returns the commands to set and delete to go from old to new.
+// Deletes remove the outermost node that no longer has any config below it.
+func diffConfig(old, new []configCommand) (set, del []configCommand) {
+	key := func(c configCommand) string {
+		return strings.Join(append(c.path[:len(c.path):len(c.path)], c.value), "\x00")
+	}
+	isPrefix := func(prefix, path []string) bool {
+		if len(prefix) > len(path) {
+			return false
+		}
+		for i := range prefix {
+			if prefix[i] != path[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	oldKeys, newKeys := map[string]bool{}, map[string]bool{}
+	for _, c := range old {
+		oldKeys[key(c)] = true
+	}
+	for _, c := range new {
+		newKeys[key(c)] = true
+		if !oldKeys[key(c)] {
+			set = append(set, c)
+		}
+	}
+
+	deleted := map[string]bool{}
+	for _, c := range old {
+		if newKeys[key(c)] {
+			continue
+		}
+
+		var d *configCommand
+	prefixes:
+		for i := max(1, c.owner); i <= len(c.path); i++ {
+			for _, n := range new {
+				if isPrefix(c.path[:i], n.path) {
+					continue prefixes
+				}
+			}
+			d = &configCommand{path: c.path[:i]}
+			break
+		}
+		if d == nil && c.multi {
+			// The node still has other values
+			d = &configCommand{path: c.path, value: c.value, multi: true}
+		}
+		if d == nil || deleted[key(*d)] {
+			continue
+		}
+		deleted[key(*d)] = true
+		del = append(del, *d)
+	}
+	return set, del
+}
+
+// configCommandMap converts commands to the map accepted by client.Config.Set/Delete.
+func configCommandMap(commands []configCommand) map[string]interface{} {
+	m := map[string]interface{}{}
+	for _, c := range commands {
+		key := strings.Join(c.path, " ")
+		switch existing := m[key].(type) {
+		case []string:
+			m[key] = append(existing, c.value)
+		case string:
+			m[key] = []string{existing, c.value}
+		default:
+			if c.multi {
+				m[key] = []string{c.value}
+			} else {
+				m[key] = c.value
+			}
+		}
+	}
+	return m
+}
+
+func lookupConfig(tree map[string]interface{}, path string) (interface{}, bool) {
+	var node interface{} = tree
+	for _, word := range strings.Fields(path) {
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = m[word]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func configMap(node interface{}) map[string]interface{} {
+	if m, ok := node.(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+// configStrings returns the values of a leaf node, which the API returns
+// as a string or, for multi-value nodes, a list. The values of a tag node
+// are its instances.
+func configStrings(node interface{}) []string {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		return sortedConfigKeys(node)
+	case string:
+		return []string{node}
+	case []interface{}:
+		values := []string{}
+		for _, v := range node {
+			values = append(values, fmt.Sprint(v))
+		}
+		return values
+	case []string:
+		return node
+	}
+	return nil
+}
+
+func configItems(value interface{}) []interface{} {
+	switch value := value.(type) {
+	case *schema.Set:
+		return value.List()
+	case []interface{}:
+		return value
+	}
+	return nil
+}
+
+func configString(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case int:
+		return strconv.Itoa(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return ""
+}
+
+// configScalar converts a config value to the type of s.
+func configScalar(s *schema.Schema, value string) (interface{}, bool) {
+	if s == nil {
+		return value, true
+	}
+	switch s.Type {
+	case schema.TypeInt:
+		v, err := strconv.Atoi(value)
+		return v, err == nil
+	case schema.TypeFloat:
+		v, err := strconv.ParseFloat(value, 64)
+		return v, err == nil
+	default:
+		return value, true
+	}
+}
+
+// sortedConfigKeys sorts tag node names, numerically if possible.
+func sortedConfigKeys(tree map[string]interface{}) []string {
+	keys := make([]string, 0, len(tree))
+	for k := range tree {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
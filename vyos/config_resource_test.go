@@ -0,0 +1,145 @@
+package vyos
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// memoryConfig is a router config held in memory, in the tree format the
+// API shows.
+type memoryConfig struct {
+	mu   sync.Mutex
+	tree map[string]any
+}
+
+func (m *memoryConfig) Show(ctx context.Context, path string) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	node, ok := lookupConfig(m.tree, path)
+	if !ok {
+		return nil, nil
+	}
+	return node, nil
+}
+
+func (m *memoryConfig) Set(ctx context.Context, path string, value any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	base := strings.Fields(path)
+	commands, ok := value.(map[string]interface{})
+	if !ok {
+		setConfigTree(m.tree, configCommand{path: base, value: configString(value)})
+		return nil
+	}
+	for key, value := range commands {
+		full := append(base[:len(base):len(base)], strings.Fields(key)...)
+		if values, ok := value.([]string); ok {
+			for _, v := range values {
+				setConfigTree(m.tree, configCommand{path: full, value: v, multi: true})
+			}
+		} else {
+			setConfigTree(m.tree, configCommand{path: full, value: configString(value)})
+		}
+	}
+	return nil
+}
+
+func (m *memoryConfig) Delete(ctx context.Context, path string, values ...any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	words := strings.Fields(path)
+	if parent, ok := lookupConfig(m.tree, strings.Join(words[:len(words)-1], " ")); ok {
+		delete(configMap(parent), words[len(words)-1])
+	}
+	return nil
+}
+
+func (m *memoryConfig) Save(ctx context.Context) error { return nil }
+
+func (m *memoryConfig) SaveFile(ctx context.Context, file string) error { return nil }
+
+// newTestProvider returns a provider for a router of the given version
+// holding tree, without a cache or saves.
+func newTestProvider(t *testing.T, version vyosVersion, tree map[string]any) (*ProviderClass, *memoryConfig) {
+	config := &memoryConfig{tree: tree}
+	return &ProviderClass{
+		schema:          schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"cache": false}),
+		client:          &routerClient{Config: &routerConfig{config: config, limiter: newRequestLimiter(4, 1)}},
+		version:         version,
+		_showCacheMutex: &sync.Mutex{},
+	}, config
+}
+
+func TestInterfaceVRF(t *testing.T) {
+	ctx := context.Background()
+	p, config := newTestProvider(t, vyos14, map[string]any{
+		"vrf": map[string]any{"name": map[string]any{"blue": map[string]any{"table": "100"}}},
+	})
+	res := Provider().ResourcesMap["vyos_interface_tunnel"]
+
+	diff, err := res.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":           "tun0",
+		"encapsulation":  "gre",
+		"source_address": "192.0.2.1",
+		"remote":         "198.51.100.1",
+		"vrf":            "blue",
+	}), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attr := diff.Attributes["vrf"]; attr == nil || attr.New != "blue" {
+		t.Fatalf("planned vrf %v, want blue", attr)
+	}
+
+	state, diags := res.Apply(ctx, nil, diff, p)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if vrf, _ := lookupConfig(config.tree, "interfaces tunnel tun0 vrf"); vrf != "blue" {
+		t.Fatalf("router vrf %v, want blue", vrf)
+	}
+
+	state, diags = res.RefreshWithoutUpgrade(ctx, state, p)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if vrf := state.Attributes["vrf"]; vrf != "blue" {
+		t.Fatalf("read vrf %q, want blue", vrf)
+	}
+}
+
+// unknownValue is how raw test configurations mark a value unknown.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestUnknownDevicePlans(t *testing.T) {
+	// Only devices, no url
+	p := &ProviderClass{devices: map[string]*ProviderClass{}}
+
+	tests := map[string]map[string]interface{}{
+		"vyos_interface_tunnel": {
+			"name":           "tun0",
+			"encapsulation":  "gre",
+			"source_address": "192.0.2.1",
+			"remote":         "198.51.100.1",
+		},
+		"vyos_config": {
+			"key":   "system host-name",
+			"value": "router",
+		},
+		"vyos_config_block": {
+			"path":    "system",
+			"configs": map[string]interface{}{"host-name": "router"},
+		},
+	}
+	for name, config := range tests {
+		config["device"] = unknownValue
+		if _, err := Provider().ResourcesMap[name].Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), p); err != nil {
+			t.Errorf("%s: %s", name, err)
+		}
+	}
+}
@@ -13,6 +13,7 @@ func dataSourceConfig() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceConfigRead,
 		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
 			"key": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -30,7 +31,10 @@ func dataSourceConfig() *schema.Resource {
 }
 
 func dataSourceConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	key := d.Get("key").(string)
 
 	value, err := p.ShowCached(ctx, key)
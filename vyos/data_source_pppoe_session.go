@@ -0,0 +1,90 @@
+package vyos
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePPPoESession() *schema.Resource {
+	computed := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Session state and negotiated addresses of a PPPoE interface, from `show interfaces pppoe <name>`.",
+		ReadContext: dataSourcePPPoESessionRead,
+		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
+			"name": {
+				Description: "Interface name, e.g. `pppoe0`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"connected":      computed(schema.TypeBool, "Whether the session is up."),
+			"local_address":  computed(schema.TypeString, "Negotiated IPv4 address."),
+			"remote_address": computed(schema.TypeString, "IPv4 address of the peer."),
+			"mtu":            computed(schema.TypeInt, "Negotiated MTU."),
+			"ipv6_addresses": {
+				Description: "Global IPv6 addresses with prefix length.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read:    schema.DefaultTimeout(10 * time.Minute),
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func dataSourcePPPoESessionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	name := d.Get("name").(string)
+
+	// The interface only exists while a session is established, any other
+	// output means it is disconnected
+	output, err := p.api.Show(ctx, "interfaces pppoe "+name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	link := parseShowLink(output).Fields
+	if link["interface"] != name {
+		link = map[string]string{}
+	}
+
+	mtu, _ := strconv.Atoi(link["mtu"])
+	ipv6 := []string{}
+	if link["ipv6_addresses"] != "" {
+		ipv6 = strings.Split(link["ipv6_addresses"], ",")
+	}
+	values := map[string]interface{}{
+		"connected":      link["up"] == "true",
+		"local_address":  link["address"],
+		"remote_address": link["peer"],
+		"mtu":            mtu,
+		"ipv6_addresses": ipv6,
+	}
+	for attr, value := range values {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,87 @@
+package vyos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceShow() *schema.Resource {
+	return &schema.Resource{
+		Description: "Runs an op-mode show command and returns its output. The output of `version`, `interfaces`, `dhcp server leases`, `ip route`, `ipv6 route`, `system image` and `vrrp` is also parsed into `fields` or `records`.",
+		ReadContext: dataSourceShowRead,
+		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
+			"command": {
+				Description:      "Show command without the leading `show`, e.g. `interfaces`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+			},
+			"output": {
+				Description: "Raw command output.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"fields": {
+				Description: "Key/value lines of the output with snake_cased keys, for commands such as `version`.",
+				Type:        schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+			"records": {
+				Description: "Table rows of the output keyed by snake_cased column headers, for commands such as `interfaces`. Multiple values in a column are separated by commas.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read:    schema.DefaultTimeout(10 * time.Minute),
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func dataSourceShowRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	command := d.Get("command").(string)
+
+	output, err := p.api.Show(ctx, command)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("output", output); err != nil {
+		return diag.FromErr(err)
+	}
+
+	parsed, _ := parseShow(command, output)
+	if err := d.Set("fields", parsed.Fields); err != nil {
+		return diag.FromErr(err)
+	}
+	records := make([]interface{}, len(parsed.Records))
+	for i, record := range parsed.Records {
+		records[i] = record
+	}
+	if err := d.Set("records", records); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,122 @@
+package vyos
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSystemInfo() *schema.Resource {
+	computed := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeString,
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Version, hardware and image information of the router, e.g. to gate features on the VyOS version or assert router identity.",
+		ReadContext: dataSourceSystemInfoRead,
+		Schema: map[string]*schema.Schema{
+			"device":             deviceSchema(),
+			"version":            computed("VyOS version without the `VyOS` prefix, e.g. `1.4.0`."),
+			"release_train":      computed("Release train, e.g. `sagitta`."),
+			"built_on":           computed("Build date."),
+			"build_commit_id":    computed("Commit the image was built from."),
+			"architecture":       computed("CPU architecture, e.g. `x86_64`."),
+			"hardware_vendor":    computed("Hardware vendor."),
+			"hardware_model":     computed("Hardware model."),
+			"hardware_serial":    computed("Hardware serial number."),
+			"hardware_uuid":      computed("Hardware UUID."),
+			"hostname":           computed("Configured `system host-name`."),
+			"uptime":             computed("System uptime as reported by `show system uptime`."),
+			"default_boot_image": computed("Image booted by default."),
+			"running_image":      computed("Currently running image."),
+			"images": {
+				Description: "Installed images.",
+				Type:        schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read:    schema.DefaultTimeout(10 * time.Minute),
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func dataSourceSystemInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := p.api.Show(ctx, "version")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	version, _ := parseShow("version", output)
+
+	output, err = p.api.Show(ctx, "system image")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	images, _ := parseShow("system image", output)
+
+	uptime, err := p.api.Show(ctx, "system uptime")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if fields := parseShowFields(uptime).Fields; fields["uptime"] != "" {
+		// 1.4 and later: "Uptime: 1d 2h 3m 4s" followed by load averages
+		uptime = fields["uptime"]
+	}
+
+	hostname, err := p.ShowCached(ctx, "system host-name")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"version":         strings.TrimSpace(strings.TrimPrefix(version.Fields["version"], "VyOS")),
+		"release_train":   version.Fields["release_train"],
+		"built_on":        version.Fields["built_on"],
+		"build_commit_id": version.Fields["build_commit_id"],
+		"architecture":    version.Fields["architecture"],
+		"hardware_vendor": version.Fields["hardware_vendor"],
+		"hardware_model":  version.Fields["hardware_model"],
+		"hardware_serial": version.Fields["hardware_s_n"],
+		"hardware_uuid":   version.Fields["hardware_uuid"],
+		"hostname":        configString(hostname),
+		"uptime":          strings.TrimSpace(uptime),
+	}
+
+	names := []string{}
+	for _, image := range images.Records {
+		names = append(names, image["name"])
+		if image["default_boot"] == "true" {
+			values["default_boot_image"] = image["name"]
+		}
+		if image["running"] == "true" {
+			values["running_image"] = image["name"]
+		}
+	}
+	values["images"] = names
+
+	for attr, value := range values {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,98 @@
+package vyos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVRRPState() *schema.Resource {
+	computed := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Computed:    true,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Current state of the VRRP groups as reported by `show vrrp`, e.g. to assert which router is master. Groups are empty if VRRP is not running.",
+		ReadContext: dataSourceVRRPStateRead,
+		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
+			"name": {
+				Description: "Group to report the `state` of. Reading fails if the group is not running.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"state": computed(schema.TypeString, "State of the group `name`, e.g. `MASTER`, `BACKUP` or `FAULT`."),
+			"groups": {
+				Description: "All running groups.",
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":            computed(schema.TypeString, "Name of the group."),
+						"interface":       computed(schema.TypeString, "Interface of the group."),
+						"vrid":            computed(schema.TypeInt, "Virtual router ID."),
+						"state":           computed(schema.TypeString, "State, e.g. `MASTER`, `BACKUP` or `FAULT`."),
+						"priority":        computed(schema.TypeInt, "Priority of this router."),
+						"last_transition": computed(schema.TypeString, "Time since the last state change."),
+					},
+				},
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Read:    schema.DefaultTimeout(10 * time.Minute),
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func dataSourceVRRPStateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	output, err := p.api.Show(ctx, "vrrp")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	vrrp, _ := parseShow("vrrp", output)
+
+	name := d.Get("name").(string)
+	state := ""
+	groups := []interface{}{}
+	for _, record := range vrrp.Records {
+		vrid, _ := strconv.Atoi(record["vrid"])
+		priority, _ := strconv.Atoi(record["priority"])
+		groups = append(groups, map[string]interface{}{
+			"name":            record["name"],
+			"interface":       record["interface"],
+			"vrid":            vrid,
+			"state":           record["state"],
+			"priority":        priority,
+			"last_transition": record["last_transition"],
+		})
+		if record["name"] == name {
+			state = record["state"]
+		}
+	}
+	if name != "" && state == "" {
+		return diag.Errorf("VRRP group '%s' is not running.", name)
+	}
+
+	if err := d.Set("state", state); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("groups", groups); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return diag.Diagnostics{}
+}
@@ -0,0 +1,56 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deviceSchema is the `device` attribute every resource and data source has.
+func deviceSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Name of the provider `device` to manage. Uses the provider `url` if not set.",
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+	}
+}
+
+// providerDevice returns the ProviderClass of the router a resource targets.
+// d is a *schema.ResourceData or *schema.ResourceDiff.
+func providerDevice(m interface{}, d interface{ Get(string) interface{} }) (*ProviderClass, error) {
+	p := m.(*ProviderClass)
+	name, _ := d.Get("device").(string)
+
+	if name == "" {
+		if p.client == nil {
+			return nil, fmt.Errorf("`device` must be set since the provider has no `url`")
+		}
+		return p, nil
+	}
+
+	device, ok := p.devices[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown device '%s', it must be one of the provider `device` blocks", name)
+	}
+	return device, nil
+}
+
+// importDevice wraps an import function to accept IDs of the form
+// "<device>@<id>", setting the `device` attribute.
+func importDevice(importer schema.StateContextFunc) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if device, id, ok := strings.Cut(d.Id(), "@"); ok {
+			if err := d.Set("device", device); err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+		}
+		if _, err := providerDevice(m, d); err != nil {
+			return nil, err
+		}
+		return importer(ctx, d, m)
+	}
+}
@@ -0,0 +1,53 @@
+package vyos
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// VyOS names the node a commit failed on in brackets, e.g.
+// "[ interfaces ethernet eth0 ]" or "Configuration path: [system foo] is not valid".
+var failingPathPattern = regexp.MustCompile(`\[\[?\s*([^\[\]]+?)\s*\]\]?`)
+
+// configError is an error of a config request, split into the commit
+// output of VyOS and the path it failed on.
+type configError struct {
+	Message string
+	Path    []string
+}
+
+func parseConfigError(err error) configError {
+	lines := []string{}
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	e := configError{Message: strings.Join(lines, "\n")}
+	if m := failingPathPattern.FindStringSubmatch(e.Message); m != nil {
+		e.Path = strings.Fields(m[1])
+	}
+	return e
+}
+
+// configErrorDiags converts the error of a request to `action` the config at
+// path to diagnostics, pointing at attr when it is known.
+func configErrorDiags(err error, action, path string, attr cty.Path) diag.Diagnostics {
+	e := parseConfigError(err)
+	detail := e.Message
+	if len(e.Path) > 0 {
+		detail += fmt.Sprintf("\n\nFailing path: %s", strings.Join(e.Path, " "))
+	}
+
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("Failed to %s '%s'", action, path),
+		Detail:        detail,
+		AttributePath: attr,
+	}}
+}
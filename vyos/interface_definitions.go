@@ -0,0 +1,35 @@
+package vyos
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkConfig validates "set <path> <value>" for each value against the
+// interface definitions, an empty value meaning no value at all. It does
+// nothing unless the provider was configured with interface_definitions.
+func (p *ProviderClass) checkConfig(path string, values ...string) error {
+	if p.definitions == nil {
+		return nil
+	}
+
+	words := strings.Fields(path)
+	if len(values) == 0 {
+		values = []string{""}
+	}
+
+	for _, value := range values {
+		cmd := words
+		if value != "" {
+			cmd = append(words[:len(words):len(words)], value)
+		}
+		node, err := p.definitions.Check(cmd)
+		if err != nil {
+			return err
+		}
+		if len(values) > 1 && !node.Multi {
+			return fmt.Errorf("'%s' does not accept multiple values", path)
+		}
+	}
+	return nil
+}
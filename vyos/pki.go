@@ -0,0 +1,224 @@
+package vyos
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// VyOS stores PKI objects as the base64 DER body of their PEM encoding,
+// without headers or line breaks. pemObject converts the PEM input of an
+// attribute to that body, and back when reading.
+type pemObject struct {
+	// PEM block type stored by VyOS
+	Type string
+
+	// convert returns the DER of other accepted block types as Type.
+	convert func(block *pem.Block) ([]byte, error)
+}
+
+var (
+	pemCertificate = pemObject{Type: "CERTIFICATE"}
+	pemPublicKey   = pemObject{Type: "PUBLIC KEY", convert: convertPublicKey}
+	pemPrivateKey  = pemObject{Type: "PRIVATE KEY", convert: convertPrivateKey}
+)
+
+// der returns the DER of the first PEM block of value.
+func (o pemObject) der(value string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(value)))
+	if block == nil {
+		return nil, fmt.Errorf("expected a PEM encoded %s", strings.ToLower(o.Type))
+	}
+	if block.Type == o.Type {
+		return block.Bytes, nil
+	}
+	if o.convert != nil {
+		return o.convert(block)
+	}
+	return nil, fmt.Errorf("expected a PEM %s block, got %s", o.Type, block.Type)
+}
+
+// body is the value VyOS stores for the PEM value, empty if it is invalid.
+func (o pemObject) body(value string) string {
+	der, err := o.der(value)
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+// encode wraps a stored body into PEM.
+func (o pemObject) encode(body string) string {
+	der, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return ""
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: o.Type, Bytes: der}))
+}
+
+func (o pemObject) validate(value interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := o.der(value.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid PEM",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// Keys are stored as PKCS #8 and PKIX, PKCS #1 and SEC 1 ones as the tls
+// provider outputs by default are converted.
+func convertPrivateKey(block *pem.Block) ([]byte, error) {
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "ENCRYPTED PRIVATE KEY":
+		return nil, fmt.Errorf("encrypted private keys are not supported")
+	default:
+		return nil, fmt.Errorf("expected a PEM PRIVATE KEY block, got %s", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return x509.MarshalPKCS8PrivateKey(key)
+}
+
+func convertPublicKey(block *pem.Block) ([]byte, error) {
+	if block.Type != "RSA PUBLIC KEY" {
+		return nil, fmt.Errorf("expected a PEM PUBLIC KEY block, got %s", block.Type)
+	}
+	key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return x509.MarshalPKIXPublicKey(key)
+}
+
+// pemAttribute maps a PEM attribute onto the node storing its body.
+type pemAttribute struct {
+	Attr   string
+	Node   string
+	Object pemObject
+}
+
+func expandPEM(attrs []pemAttribute) func(get func(string) interface{}) []configCommand {
+	return func(get func(string) interface{}) []configCommand {
+		commands := []configCommand{}
+		for _, a := range attrs {
+			if body := a.Object.body(configString(get(a.Attr))); body != "" {
+				node := strings.Fields(a.Node)
+				commands = append(commands, configCommand{path: node, value: body, owner: len(node)})
+			}
+		}
+		return commands
+	}
+}
+
+// flattenPEM reads the PEM attributes back, keeping the configured value
+// if it encodes the stored body so formatting differences are no diff. The
+// fingerprint is that of fingerprintAttr.
+func flattenPEM(attrs []pemAttribute, fingerprintAttr string) func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+	return func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+		values := map[string]interface{}{}
+		for _, a := range attrs {
+			node, _ := lookupConfig(tree, a.Node)
+			stored := configStrings(node)
+			if len(stored) == 0 {
+				values[a.Attr] = nil
+				continue
+			}
+			if configured := configString(get(a.Attr)); a.Object.body(configured) == stored[0] {
+				values[a.Attr] = configured
+			} else {
+				values[a.Attr] = a.Object.encode(stored[0])
+			}
+			if a.Attr == fingerprintAttr {
+				values["fingerprint"] = pemFingerprint(a.Object, values[a.Attr].(string))
+			}
+		}
+		return values
+	}
+}
+
+// pemFingerprint is the hex SHA-256 of the DER encoding.
+func pemFingerprint(o pemObject, value string) string {
+	der, err := o.der(value)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:])
+}
+
+// pemCustomizeDiff plans the fingerprint of attr, so it is known before
+// apply.
+func pemCustomizeDiff(attr string, o pemObject) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if !d.HasChange(attr) {
+			return nil
+		}
+		if !d.NewValueKnown(attr) {
+			return d.SetNewComputed("fingerprint")
+		}
+		return d.SetNew("fingerprint", pemFingerprint(o, d.Get(attr).(string)))
+	}
+}
+
+// pkiCertificateResource is a certificate with an optional private key,
+// stored at path.
+func pkiCertificateResource(description, path string) *schema.Resource {
+	attrs := []pemAttribute{
+		{Attr: "certificate", Node: "certificate", Object: pemCertificate},
+		{Attr: "private_key", Node: "private key", Object: pemPrivateKey},
+	}
+
+	r := &configResource{
+		Description: description,
+		Path:        path,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name the certificate is referred to by.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"certificate": {
+				Description:      "PEM encoded certificate.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: pemCertificate.validate,
+			},
+			"private_key": {
+				Description:      "PEM encoded private key. PKCS #1 and SEC 1 keys are converted to PKCS #8, encrypted keys are not supported.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: pemPrivateKey.validate,
+			},
+			"fingerprint": {
+				Description: "Hex encoded SHA-256 fingerprint of the DER encoded certificate.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Expand:        expandPEM(attrs),
+		Flatten:       flattenPEM(attrs, "certificate"),
+		CustomizeDiff: pemCustomizeDiff("certificate", pemCertificate),
+	}
+	return r.Resource()
+}
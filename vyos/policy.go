@@ -0,0 +1,78 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Policy rules are sets keyed by their number, which orders them on the
+// router, so reordering them in the configuration does not change anything.
+func policyRulesSchema(description string, rule map[string]*schema.Schema) *schema.Schema {
+	rule["number"] = &schema.Schema{
+		Description:      "Rule number, rules are evaluated in ascending order.",
+		Type:             schema.TypeInt,
+		Required:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 65535)),
+	}
+	rule["action"] = &schema.Schema{
+		Description:      "`permit` or `deny`.",
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"permit", "deny"}, false)),
+	}
+	rule["description"] = &schema.Schema{
+		Description: "Description.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: rule,
+		},
+		Optional: true,
+	}
+}
+
+func policyRulesField(fields ...configField) configField {
+	return configField{Attr: "rule", Node: "rule", Key: "number", Fields: append([]configField{
+		{Attr: "action", Node: "action"},
+		{Attr: "description", Node: "description"},
+	}, fields...)}
+}
+
+// policyRegexListResource is a list of rules matching a regular expression,
+// the format of community and AS path lists.
+func policyRegexListResource(description, path, matched string) *schema.Resource {
+	r := &configResource{
+		Description: description,
+		Path:        path,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the list.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"rule": policyRulesSchema("Rules of the list.", map[string]*schema.Schema{
+				"regex": {
+					Description: "Regular expression matching " + matched + ".",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+			}),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			policyRulesField(configField{Attr: "regex", Node: "regex"}),
+		},
+	}
+	return r.Resource()
+}
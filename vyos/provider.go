@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strings"
 	"sync"
@@ -12,18 +14,22 @@ import (
 	"github.com/foltik/vyos-client-go/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/foltik/terraform-provider-vyos/internal/interfacedef"
 )
 
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "API URL of the router. Required unless only `device` blocks are used.",
 			},
 			"key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("VYOS_KEY", nil),
 			},
@@ -31,6 +37,54 @@ func Provider() *schema.Provider {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"device": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional routers managed by this provider. Resources select one with their `device` attribute, and use `url` otherwise.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name used in the `device` attribute of resources.",
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"cert": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"vyos_version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "VyOS version of the router, detected like the provider `vyos_version` by default.",
+						},
+						"save": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Save after making changes on the router. Uses the provider `save` by default.",
+						},
+						"save_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "File to save the router configuration. Uses the provider `save_file` by default.",
+						},
+						"save_mode": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"immediate", "deferred"}, false)),
+							Description:      "When to save after making changes on the router. Uses the provider `save_mode` by default.",
+						},
+					},
+				},
+			},
 			"save": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -42,40 +96,255 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "File to save configuration. Uses config.boot by default.",
 			},
+			"save_mode": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "immediate",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"immediate", "deferred"}, false)),
+				Description:      "When to save after making changes. `immediate` saves after every change. `deferred` saves changes every `save_interval` seconds, reporting a failure with the next change, and tries to save once more when the provider shuts down at the end of the apply. Terraform stops the provider before that save may finish, so end the apply with a `vyos_config_save` depending on the other resources to make sure the changes are saved.",
+			},
+			"save_interval": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          60,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "Seconds between saves of pending changes in the `deferred` save mode.",
+			},
+			"save_failure": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "error",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"error", "warning"}, false)),
+				Description:      "Whether a failure to save after making changes is reported as an `error` or a `warning`.",
+			},
 			"cache": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
 				Description: "Use cache for read operations",
 			},
+			"vyos_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "VyOS version of the router, e.g. `1.3` or `1.4`. Typed resources translate their paths to its syntax. Detected with `show version` by default. If that fails, typed resources refuse to plan changes, but can still be refreshed, imported and destroyed.",
+			},
+			"max_requests": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     4,
+				Description: "Maximum number of API requests in flight per router. Requests failing because the config is locked or with a transient error are retried until the resource timeout. Config changes are only retried when the router did not handle them, on a locked config, a refused connection or a 503 response.",
+			},
+			"max_parallel_writes": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Maximum number of config changes made to a router at once. Further changes wait in the order they were made, reads are not affected.",
+			},
+			"session_user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Login user the provider's own access depends on, e.g. the user of an SSH tunnel to the API. `vyos_system_user` refuses to delete it. VyOS API keys do not belong to a login user, so nothing is protected when this is not set.",
+			},
+			"interface_definitions": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VYOS_INTERFACE_DEFINITIONS", nil),
+				Description: "Path to a VyOS interface-definition XML file, or a directory of them. When set, the config paths and values of all resources, `vyos_config` and `vyos_config_block` included, are validated against it during plan. Otherwise they are only validated by the router during apply. The definitions of a router are in /usr/share/vyos/interface-definitions.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"vyos_config":              resourceConfig(),
-			"vyos_config_block":        resourceConfigBlock(),
-			"vyos_config_block_tree":   resourceConfigBlockTree(),
-			"vyos_static_host_mapping": resourceStaticHostMapping(),
+			"vyos_config":                      resourceConfig(),
+			"vyos_config_block":                resourceConfigBlock(),
+			"vyos_config_block_tree":           resourceConfigBlockTree(),
+			"vyos_config_save":                 resourceConfigSave(),
+			"vyos_firewall_zone":               resourceFirewallZone(),
+			"vyos_firewall_zone_policy":        resourceFirewallZonePolicy(),
+			"vyos_interface_bond":              resourceInterfaceBond(),
+			"vyos_interface_bridge":            resourceInterfaceBridge(),
+			"vyos_interface_geneve":            resourceInterfaceGENEVE(),
+			"vyos_interface_l2tpv3":            resourceInterfaceL2TPv3(),
+			"vyos_interface_openvpn":           resourceInterfaceOpenVPN(),
+			"vyos_interface_pppoe":             resourceInterfacePPPoE(),
+			"vyos_interface_tunnel":            resourceInterfaceTunnel(),
+			"vyos_interface_vxlan":             resourceInterfaceVXLAN(),
+			"vyos_ipsec_esp_group":             resourceIPsecESPGroup(),
+			"vyos_ipsec_ike_group":             resourceIPsecIKEGroup(),
+			"vyos_ipsec_psk":                   resourceIPsecPSK(),
+			"vyos_ipsec_site_to_site_peer":     resourceIPsecSiteToSitePeer(),
+			"vyos_ospf":                        resourceOSPF(),
+			"vyos_ospf_area":                   resourceOSPFArea(),
+			"vyos_ospf_interface":              resourceOSPFInterface(),
+			"vyos_pki_ca":                      resourcePKICA(),
+			"vyos_pki_certificate":             resourcePKICertificate(),
+			"vyos_pki_key_pair":                resourcePKIKeyPair(),
+			"vyos_policy_as_path_list":         resourcePolicyASPathList(),
+			"vyos_policy_community_list":       resourcePolicyCommunityList(),
+			"vyos_policy_large_community_list": resourcePolicyLargeCommunityList(),
+			"vyos_policy_local_route":          resourcePolicyLocalRoute(),
+			"vyos_policy_prefix_list":          resourcePolicyPrefixList(),
+			"vyos_policy_route":                resourcePolicyRoute(),
+			"vyos_policy_route_map":            resourcePolicyRouteMap(),
+			"vyos_qos_cake":                    resourceQoSCake(),
+			"vyos_qos_fair_queue":              resourceQoSFairQueue(),
+			"vyos_qos_fq_codel":                resourceQoSFQCoDel(),
+			"vyos_qos_interface":               resourceQoSInterface(),
+			"vyos_qos_limiter":                 resourceQoSLimiter(),
+			"vyos_qos_shaper":                  resourceQoSShaper(),
+			"vyos_route_table":                 resourceRouteTable(),
+			"vyos_service_ntp":                 resourceServiceNTP(),
+			"vyos_static_host_mapping":         resourceStaticHostMapping(),
+			"vyos_static_route":                resourceStaticRoute(),
+			"vyos_static_route6":               resourceStaticRoute6(),
+			"vyos_system":                      resourceSystem(),
+			"vyos_system_syslog":               resourceSystemSyslog(),
+			"vyos_system_user":                 resourceSystemUser(),
+			"vyos_vrf":                         resourceVRF(),
+			"vyos_vrrp_group":                  resourceVRRPGroup(),
+			"vyos_vrrp_sync_group":             resourceVRRPSyncGroup(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"vyos_config": dataSourceConfig(),
+			"vyos_config":        dataSourceConfig(),
+			"vyos_pppoe_session": dataSourcePPPoESession(),
+			"vyos_show":          dataSourceShow(),
+			"vyos_system_info":   dataSourceSystemInfo(),
+			"vyos_vrrp_state":    dataSourceVRRPState(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for name, resource := range generatedResources {
+		provider.ResourcesMap[name] = resource
+	}
+
+	return provider
 }
 
+// ProviderClass holds the client and state for a single router. The
+// instance returned by providerConfigure is the one for the provider `url`,
+// and also holds the ones for each `device`.
 type ProviderClass struct {
-	schema *schema.ResourceData
-	client *client.Client
+	schema      *schema.ResourceData
+	client      *routerClient
+	api         *apiClient
+	version     vyosVersion
+	definitions *interfacedef.Node
+
+	// versionErr is why version is unknown. Typed resources refuse to
+	// change the router then, rather than guess its syntax, but read and
+	// delete existing config in the syntax it is found in.
+	versionErr error
+
+	name    string
+	devices map[string]*ProviderClass
+
+	// Save settings of the router, those of the provider unless set in its
+	// device block.
+	saveChanges bool
+	saveFile    string
+	saveMode    string
 
 	_showCacheMutex *sync.Mutex
 	_showCache      *map[string]any
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	url := d.Get("url").(string)
-	key := d.Get("key").(string)
+	var diags diag.Diagnostics
+	var definitions *interfacedef.Node
+	if path := d.Get("interface_definitions").(string); path != "" {
+		var err error
+		definitions, err = interfacedef.Load(path)
+		if err != nil {
+			return nil, diag.Errorf("Failed to load interface definitions: %s", err)
+		}
+	} else {
+		log.Printf("[DEBUG] No interface definitions set, config is only validated by the router during apply")
+	}
+
+	// Routers are configured concurrently, so an unreachable one does not
+	// hold up version detection of the others.
+	devices := []map[string]interface{}{}
+	if url := d.Get("url").(string); url != "" {
+		devices = append(devices, map[string]interface{}{
+			"name":         "",
+			"url":          url,
+			"key":          d.Get("key").(string),
+			"cert":         d.Get("cert").(string),
+			"vyos_version": d.Get("vyos_version").(string),
+			"save":         d.Get("save").(bool),
+			"save_file":    d.Get("save_file").(string),
+			"save_mode":    d.Get("save_mode").(string),
+		})
+	}
+	rawDevices := rawAttr(d.GetRawConfig(), "device")
+	for i, device := range d.Get("device").([]interface{}) {
+		device := device.(map[string]interface{})
+
+		// Save settings not set on the device are those of the provider
+		if rawAttr(rawElement(rawDevices, i, "", nil), "save").IsNull() {
+			device["save"] = d.Get("save").(bool)
+		}
+		for _, attr := range []string{"save_file", "save_mode"} {
+			if device[attr].(string) == "" {
+				device[attr] = d.Get(attr).(string)
+			}
+		}
+		devices = append(devices, device)
+	}
+	if len(devices) == 0 {
+		return nil, diag.Errorf("Either `url` or a `device` block must be set")
+	}
+
+	classes := make([]*ProviderClass, len(devices))
+	deviceDiags := make([]diag.Diagnostics, len(devices))
+	var wg sync.WaitGroup
+	for i, device := range devices {
+		wg.Add(1)
+		go func(i int, device map[string]interface{}) {
+			defer wg.Done()
+			classes[i], deviceDiags[i] = configureDevice(ctx, d, definitions, device)
+		}(i, device)
+	}
+	wg.Wait()
+
+	for _, dd := range deviceDiags {
+		diags = append(diags, dd...)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	root := classes[0]
+	if root.name != "" {
+		// Only devices, resources have to select one
+		root = &ProviderClass{schema: d, definitions: definitions}
+	}
+	root.devices = map[string]*ProviderClass{}
+	for _, p := range classes {
+		if p.name == "" {
+			continue
+		}
+		if _, ok := root.devices[p.name]; ok {
+			return nil, diag.Errorf("Duplicate device '%s'", p.name)
+		}
+		root.devices[p.name] = p
+	}
+
+	return root, diags
+}
+
+func configureDevice(ctx context.Context, d *schema.ResourceData, definitions *interfacedef.Node, device map[string]interface{}) (*ProviderClass, diag.Diagnostics) {
+	name := device["name"].(string)
+	url := device["url"].(string)
+	key := device["key"].(string)
+
+	cert := device["cert"].(string)
+	limiter := newRequestLimiter(d.Get("max_requests").(int), d.Get("max_parallel_writes").(int))
+	c := &routerClient{}
+	api := &apiClient{}
 
-	cert := d.Get("cert").(string)
-	c := &client.Client{}
+	if key == "" {
+		return nil, diag.Errorf("No API key set for %s", url)
+	}
 
 	if cert != "" {
 		return nil, diag.Errorf("TODO: Use trusted self signed certificate")
@@ -84,23 +353,96 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		tr := http.DefaultTransport.(*http.Transport).Clone()
 		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 		cc := &http.Client{Transport: tr, Timeout: 10 * time.Minute}
-		c = client.NewWithClient(cc, url, key)
+		c = newRouterClient(client.NewWithClient(cc, url, key), limiter)
+		api = &apiClient{cc, url, key, limiter}
 	}
 
-	return &ProviderClass{d, c, &sync.Mutex{}, nil}, diag.Diagnostics{}
-}
+	var diags diag.Diagnostics
 
-func (p *ProviderClass) conditionalSave(ctx context.Context) {
-	save := p.schema.Get("save").(bool)
-	save_file := p.schema.Get("save_file").(string)
+	// Requests are retried until the deadline, don't hang on an unreachable router
+	detectCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	version, err := detectVersion(detectCtx, api, device["vyos_version"].(string))
+	var versionErr error
+	if err != nil {
+		versionErr = fmt.Errorf("the VyOS version of %s is unknown (%s), set `vyos_version` in the provider configuration to use typed resources", url, err)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Failed to detect VyOS version of " + url,
+			Detail:   fmt.Sprintf("%s. Typed resources refuse to plan changes for this router until `vyos_version` is set in the provider configuration, but can still be refreshed, imported and destroyed. vyos_config and vyos_config_block are not affected.", err),
+		})
+	}
+
+	p := &ProviderClass{
+		schema:          d,
+		client:          c,
+		api:             api,
+		version:         version,
+		versionErr:      versionErr,
+		definitions:     definitions,
+		name:            name,
+		saveChanges:     device["save"].(bool),
+		saveFile:        device["save_file"].(string),
+		saveMode:        device["save_mode"].(string),
+		_showCacheMutex: &sync.Mutex{},
+	}
 
-	if save {
-		if save_file == "" {
-			p.client.Config.Save(ctx)
-		} else {
-			p.client.Config.SaveFile(ctx, save_file)
+	if p.saveMode == "deferred" {
+		go p.savePeriodically(time.Duration(d.Get("save_interval").(int)) * time.Second)
+	}
+
+	return p, diags
+}
+
+// detectVersion parses version if set, and asks the router otherwise.
+func detectVersion(ctx context.Context, api *apiClient, version string) (vyosVersion, error) {
+	if version == "" {
+		output, err := api.Show(ctx, "version")
+		if err != nil {
+			return vyosVersion{}, err
 		}
+		parsed, _ := parseShow("version", output)
+		version = parsed.Fields["version"]
+	}
+	return parseVersion(version)
+}
+
+func (p *ProviderClass) conditionalSave(ctx context.Context) diag.Diagnostics {
+	if !p.saveChanges {
+		return diag.Diagnostics{}
+	}
+
+	if p.saveMode == "deferred" {
+		deferredSaves.markDirty(p)
+		return deferredSaves.failures(p)
+	}
+
+	return p.save(ctx)
+}
+
+func (p *ProviderClass) save(ctx context.Context) diag.Diagnostics {
+	save_file := p.saveFile
+
+	var err error
+	if save_file == "" {
+		err = p.client.Config.Save(ctx)
+		save_file = "config.boot"
+	} else {
+		err = p.client.Config.SaveFile(ctx, save_file)
+	}
+	if err == nil {
+		return diag.Diagnostics{}
+	}
+
+	severity := diag.Error
+	if p.schema.Get("save_failure").(string) == "warning" {
+		severity = diag.Warning
 	}
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  "Failed to save the configuration to " + save_file,
+		Detail:   parseConfigError(err).Message + "\n\nThe change is applied, but will be lost when the router reboots.",
+	}}
 }
 
 func (p *ProviderClass) ShowCached(ctx context.Context, path string) (any, error) {
@@ -115,12 +457,14 @@ func (p *ProviderClass) ShowCached(ctx context.Context, path string) (any, error
 		c := *p.client
 		showCache, err := c.Config.Show(ctx, "")
 		if err != nil {
+			p._showCacheMutex.Unlock()
 			return showCache, err
 		}
 		switch value := showCache.(type) {
 		case map[string]any:
 			p._showCache = &value
 		default:
+			p._showCacheMutex.Unlock()
 			return nil, errors.New("Configuration is not a map")
 		}
 	}
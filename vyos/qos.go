@@ -0,0 +1,138 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Classes and their match rules are sets keyed by class number and rule
+// name. The number orders classes on the router, so reordering them in the
+// configuration does not change anything.
+func qosClassesSchema(description string, min, max int, class map[string]*schema.Schema) *schema.Schema {
+	class["id"] = &schema.Schema{
+		Description:      "Class number, classes are matched in ascending order.",
+		Type:             schema.TypeInt,
+		Required:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(min, max)),
+	}
+	class["description"] = &schema.Schema{
+		Description: "Description.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	class["match"] = qosMatchSchema()
+
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: class,
+		},
+		Optional: true,
+	}
+}
+
+func qosClassesField(fields ...configField) configField {
+	return configField{Attr: "class", Node: "class", Key: "id", Fields: append([]configField{
+		{Attr: "description", Node: "description"},
+		qosMatchField(),
+	}, fields...)}
+}
+
+func qosMatchSchema() *schema.Schema {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+	ip := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeList,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"source_address":      optional(schema.TypeString, "Source prefix."),
+					"source_port":         optional(schema.TypeString, "Source port, by number or service name."),
+					"destination_address": optional(schema.TypeString, "Destination prefix."),
+					"destination_port":    optional(schema.TypeString, "Destination port, by number or service name."),
+					"protocol":            optional(schema.TypeString, "Protocol, by number or name."),
+					"dscp":                optional(schema.TypeString, "DSCP value, by number or name, e.g. `ef`."),
+				},
+			},
+			Optional: true,
+		}
+	}
+
+	return &schema.Schema{
+		Description: "Rules matching the traffic of the class. Traffic matching any rule belongs to the class, all conditions of a rule must match.",
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "Name of the rule.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"description": optional(schema.TypeString, "Description."),
+				"interface":   optional(schema.TypeString, "Incoming interface."),
+				"mark":        optional(schema.TypeInt, "Firewall mark."),
+				"vif":         optional(schema.TypeInt, "VLAN ID."),
+				"ip":          ip("IPv4 conditions."),
+				"ipv6":        ip("IPv6 conditions."),
+			},
+		},
+		Optional: true,
+	}
+}
+
+func qosMatchField() configField {
+	ip := func(attr string) configField {
+		return configField{Attr: attr, Node: attr, Fields: []configField{
+			{Attr: "source_address", Node: "source address"},
+			{Attr: "source_port", Node: "source port"},
+			{Attr: "destination_address", Node: "destination address"},
+			{Attr: "destination_port", Node: "destination port"},
+			{Attr: "protocol", Node: "protocol"},
+			{Attr: "dscp", Node: "dscp"},
+		}}
+	}
+
+	return configField{Attr: "match", Node: "match", Key: "name", Fields: []configField{
+		{Attr: "description", Node: "description"},
+		{Attr: "interface", Node: "interface"},
+		{Attr: "mark", Node: "mark"},
+		{Attr: "vif", Node: "vif"},
+		ip("ip"),
+		ip("ipv6"),
+	}}
+}
+
+// qosBandwidthSchema is a rate such as `100mbit`, or a percentage of the
+// interface or policy bandwidth such as `50%`.
+func qosBandwidthSchema(description string, required bool) *schema.Schema {
+	return &schema.Schema{
+		Description: description + " A rate such as `100mbit` or a percentage such as `50%`.",
+		Type:        schema.TypeString,
+		Required:    required,
+		Optional:    !required,
+	}
+}
+
+// qosPolicySchema holds the attributes common to all policies.
+func qosPolicySchema(policy map[string]*schema.Schema) map[string]*schema.Schema {
+	policy["name"] = &schema.Schema{
+		Description: "Name of the policy.",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	policy["description"] = &schema.Schema{
+		Description: "Description.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+	return policy
+}
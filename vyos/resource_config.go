@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -16,10 +17,12 @@ func resourceConfig() *schema.Resource {
 		ReadContext:   resourceConfigRead,
 		UpdateContext: resourceConfigUpdate,
 		DeleteContext: resourceConfigDelete,
+		CustomizeDiff: resourceConfigCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importDevice(schema.ImportStatePassthroughContext),
 		},
 		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
 			"id": {
 				Description: "The resource ID, same as the `key`",
 				Type:        schema.TypeString,
@@ -46,8 +49,28 @@ func resourceConfig() *schema.Resource {
 	}
 }
 
+func resourceConfigCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// The device is only known during apply when it is computed
+	if !d.NewValueKnown("device") || !d.NewValueKnown("key") || !d.NewValueKnown("value") {
+		return nil
+	}
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return err
+	}
+
+	key, value := d.Get("key").(string), d.Get("value").(string)
+	if err := p.checkConfig(key, value); err != nil {
+		return cty.GetAttrPath("key").NewError(err)
+	}
+	return nil
+}
+
 func resourceConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	key, value := d.Get("key").(string), d.Get("value").(string)
 
@@ -65,16 +88,18 @@ func resourceConfigCreate(ctx context.Context, d *schema.ResourceData, m interfa
 
 	err = c.Config.Set(ctx, key, value)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "set", key, cty.GetAttrPath("value"))
 	}
 
 	d.SetId(key)
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
 
 func resourceConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	key := d.Id()
 
 	// Convert old unix timestamp style ID to key path for existing resources to support importing
@@ -103,29 +128,33 @@ func resourceConfigRead(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	key, value := d.Get("key").(string), d.Get("value").(string)
 
-	err := c.Config.Set(ctx, key, value)
+	err = c.Config.Set(ctx, key, value)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "set", key, cty.GetAttrPath("value"))
 	}
 
-	p.conditionalSave(ctx)
-	return diag.Diagnostics{}
+	return p.conditionalSave(ctx)
 }
 
 func resourceConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	key := d.Get("key").(string)
 
-	err := c.Config.Delete(ctx, key)
+	err = c.Config.Delete(ctx, key)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "delete", key, cty.GetAttrPath("key"))
 	}
 
-	p.conditionalSave(ctx)
-	return diag.Diagnostics{}
+	return p.conditionalSave(ctx)
 }
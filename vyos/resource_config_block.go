@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -17,10 +18,12 @@ func resourceConfigBlock() *schema.Resource {
 		ReadContext:   resourceConfigBlockRead,
 		UpdateContext: resourceConfigBlockUpdate,
 		DeleteContext: resourceConfigBlockDelete,
+		CustomizeDiff: resourceConfigBlockCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importDevice(schema.ImportStatePassthroughContext),
 		},
 		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
 			"id": {
 				Description: "The resource ID, same as the `path`",
 				Type:        schema.TypeString,
@@ -53,10 +56,37 @@ func resourceConfigBlock() *schema.Resource {
 	}
 }
 
+func resourceConfigBlockCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// The device is only known during apply when it is computed
+	if !d.NewValueKnown("device") || !d.NewValueKnown("path") || !d.NewValueKnown("configs") {
+		return nil
+	}
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return err
+	}
+
+	path := d.Get("path").(string)
+	if err := p.checkConfig(path); err != nil {
+		return cty.GetAttrPath("path").NewError(err)
+	}
+
+	configs := d.Get("configs").(map[string]interface{})
+	for _, key := range sortedConfigKeys(configs) {
+		if err := p.checkConfig(path+" "+key, configs[key].(string)); err != nil {
+			return cty.GetAttrPath("configs").IndexString(key).NewError(err)
+		}
+	}
+	return nil
+}
+
 func resourceConfigBlockCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	client := *p.client
 	path := d.Get("path").(string)
 
@@ -75,18 +105,20 @@ func resourceConfigBlockCreate(ctx context.Context, d *schema.ResourceData, m in
 
 	err = client.Config.Set(ctx, path, configs)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "set", path, cty.GetAttrPath("configs"))
 	}
 
 	d.SetId(path)
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
 
 func resourceConfigBlockRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	path := d.Id()
 
 	configs, err := p.ShowCached(ctx, path)
@@ -121,7 +153,10 @@ func resourceConfigBlockRead(ctx context.Context, d *schema.ResourceData, m inte
 func resourceConfigBlockUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 
 	path := d.Get("path").(string)
@@ -141,30 +176,31 @@ func resourceConfigBlockUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 	errDel := c.Config.Delete(ctx, path, deleted_attrs)
 	if errDel != nil {
-		return diag.FromErr(errDel)
+		return configErrorDiags(errDel, "delete", path, cty.GetAttrPath("configs"))
 	}
 
 	errSet := c.Config.Set(ctx, path, new_configs)
 	if errSet != nil {
-		return diag.FromErr(errSet)
+		return configErrorDiags(errSet, "set", path, cty.GetAttrPath("configs"))
 	}
 
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
 
 func resourceConfigBlockDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	path := d.Get("path").(string)
 
-	err := c.Config.Delete(ctx, path)
+	err = c.Config.Delete(ctx, path)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "delete", path, cty.GetAttrPath("path"))
 	}
 
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
@@ -0,0 +1,53 @@
+package vyos
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/foltik/terraform-provider-vyos/internal/interfacedef"
+)
+
+const testDummyDefinition = `<?xml version="1.0"?>
+<interfaceDefinition>
+  <node name="interfaces">
+    <children>
+      <tagNode name="dummy">
+        <children>
+          <leafNode name="mtu">
+            <properties>
+              <constraint>
+                <validator name="numeric" argument="--range 68-16000"/>
+              </constraint>
+            </properties>
+          </leafNode>
+        </children>
+      </tagNode>
+    </children>
+  </node>
+</interfaceDefinition>`
+
+func TestConfigBlockDefinitionErrorPath(t *testing.T) {
+	p, _ := newTestProvider(t, vyos14, map[string]any{})
+	p.definitions = interfacedef.New()
+	if err := p.definitions.Parse(strings.NewReader(testDummyDefinition)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Provider().ResourcesMap["vyos_config_block"].Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"path":    "interfaces dummy dum0",
+		"configs": map[string]interface{}{"mtu": "20"},
+	}), p)
+
+	var pathErr cty.PathError
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("got error %v, want a cty.PathError", err)
+	}
+	if want := cty.GetAttrPath("configs").IndexString("mtu"); !pathErr.Path.Equals(want) {
+		t.Fatalf("error path %#v, want %#v", pathErr.Path, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -22,10 +23,12 @@ func resourceConfigBlockTree() *schema.Resource {
 		ReadContext:   resourceConfigBlockTreeRead,
 		UpdateContext: resourceConfigBlockTreeUpdate,
 		DeleteContext: resourceConfigBlockTreeDelete,
+		CustomizeDiff: resourceConfigBlockTreeCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importDevice(schema.ImportStatePassthroughContext),
 		},
 		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
 			"id": {
 				Description: "The resource ID, same as the `path`",
 				Type:        schema.TypeString,
@@ -77,6 +80,30 @@ func configDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 	return reflect.DeepEqual(multivalueOld, multivalueNew)
 }
 
+func resourceConfigBlockTreeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// The device is only known during apply when it is computed
+	if !d.NewValueKnown("device") || !d.NewValueKnown("path") || !d.NewValueKnown("configs") {
+		return nil
+	}
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return err
+	}
+
+	path := d.Get("path").(string)
+	if err := p.checkConfig(path); err != nil {
+		return cty.GetAttrPath("path").NewError(err)
+	}
+
+	commands := getCommandsForConfig(d.Get("configs"), false)
+	for _, key := range sortedConfigKeys(commands) {
+		if err := p.checkConfig(path+" "+key, commands[key].([]string)...); err != nil {
+			return cty.GetAttrPath("configs").IndexString(key).NewError(err)
+		}
+	}
+	return nil
+}
+
 // Covert configs to a set of vyos client commands.
 // If expand_slice is set, then list values (json encoded) are expanded in multiple vyos client commands
 // If expand_slice is not set then the values in the map might contain slices
@@ -112,27 +139,32 @@ func getCommandsForConfig(config interface{}, expand_slice bool) (commands map[s
 func resourceConfigBlockTreeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	client := *p.client
 	path := d.Get("path").(string)
 
 	// Get commands needed to create resource in Vyos
 	commands := getCommandsForConfig(d.Get("configs"), true)
 
-	err := client.Config.Set(ctx, path, commands)
+	err = client.Config.Set(ctx, path, commands)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "set", path, cty.GetAttrPath("configs"))
 	}
 
 	d.SetId(path)
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
 
 func resourceConfigBlockTreeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	//c := *p.client
 	path := d.Id()
 
@@ -193,7 +225,10 @@ func resourceConfigBlockTreeRead(ctx context.Context, d *schema.ResourceData, m
 func resourceConfigBlockTreeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 
 	path := d.Get("path").(string)
@@ -229,7 +264,7 @@ func resourceConfigBlockTreeUpdate(ctx context.Context, d *schema.ResourceData,
 	if len(new_comands) > 0 {
 		errSet := c.Config.Set(ctx, path, new_comands)
 		if errSet != nil {
-			return diag.FromErr(errSet)
+			return configErrorDiags(errSet, "set", path, cty.GetAttrPath("configs"))
 		}
 	}
 
@@ -296,26 +331,27 @@ func resourceConfigBlockTreeUpdate(ctx context.Context, d *schema.ResourceData,
 	if len(delete_commands) > 0 {
 		errDel := c.Config.Delete(ctx, path, delete_commands)
 		if errDel != nil {
-			return diag.FromErr(errDel)
+			return configErrorDiags(errDel, "delete", path, cty.GetAttrPath("configs"))
 		}
 	}
 
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
 
 func resourceConfigBlockTreeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	path := d.Get("path").(string)
 
-	err := c.Config.Delete(ctx, path)
+	err = c.Config.Delete(ctx, path)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "delete", path, cty.GetAttrPath("path"))
 	}
 
-	p.conditionalSave(ctx)
-	return diags
+	return append(diags, p.conditionalSave(ctx)...)
 }
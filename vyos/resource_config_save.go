@@ -0,0 +1,102 @@
+package vyos
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceConfigSave() *schema.Resource {
+	return &schema.Resource{
+		Description:   "This resource saves the configuration when it is created, e.g. as a save point other resources `depends_on` with the `deferred` provider `save_mode`. Unless the router saves every change immediately, it plans another save on every apply, so changes made by later applies are saved too. Deleting it does nothing.",
+		CreateContext: resourceConfigSaveCreate,
+		ReadContext:   resourceConfigSaveRead,
+		UpdateContext: resourceConfigSaveUpdate,
+		DeleteContext: resourceConfigSaveDelete,
+		CustomizeDiff: resourceConfigSaveCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
+			"triggers": {
+				Description: "Arbitrary values which save the configuration again when changed.",
+				Type:        schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"saved_at": {
+				Description: "Time of the last save, in RFC 3339 format.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create:  schema.DefaultTimeout(10 * time.Minute),
+			Read:    schema.DefaultTimeout(10 * time.Minute),
+			Update:  schema.DefaultTimeout(10 * time.Minute),
+			Delete:  schema.DefaultTimeout(10 * time.Minute),
+			Default: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceConfigSaveCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceConfigSaveUpdate(ctx, d, m)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	return diags
+}
+
+func resourceConfigSaveUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Saves everything up to now, pending deferred changes included. A
+	// failed periodic save is superseded by this one.
+	deferredSaves.take(p)
+	deferredSaves.failures(p)
+	diags := p.save(ctx)
+	if len(diags) > 0 {
+		deferredSaves.markDirty(p)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if err := d.Set("saved_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// resourceConfigSaveCustomizeDiff plans a save on every apply, as the
+// changes of other resources in the apply may not be saved otherwise.
+func resourceConfigSaveCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("device") {
+		return nil
+	}
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return err
+	}
+	if p.saveChanges && p.saveMode == "immediate" {
+		return nil
+	}
+	return d.SetNewComputed("saved_at")
+}
+
+func resourceConfigSaveRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.Diagnostics{}
+}
+
+func resourceConfigSaveDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.Diagnostics{}
+}
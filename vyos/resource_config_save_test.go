@@ -0,0 +1,40 @@
+package vyos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConfigSavePlansEveryApply(t *testing.T) {
+	ctx := context.Background()
+	res := Provider().ResourcesMap["vyos_config_save"]
+	state := &terraform.InstanceState{ID: "1700000000", Attributes: map[string]string{
+		"id":       "1700000000",
+		"saved_at": "2023-11-14T22:13:20Z",
+	}}
+
+	tests := []struct {
+		save bool
+		mode string
+		plan bool
+	}{
+		{true, "immediate", false},
+		{true, "deferred", true},
+		{false, "immediate", true},
+	}
+	for _, tt := range tests {
+		p, _ := newTestProvider(t, vyos14, map[string]any{})
+		p.saveChanges, p.saveMode = tt.save, tt.mode
+
+		diff, err := res.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{}), p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		planned := diff != nil && diff.Attributes["saved_at"] != nil && diff.Attributes["saved_at"].NewComputed
+		if planned != tt.plan {
+			t.Errorf("save %t, save_mode %s: planned a save %t, want %t", tt.save, tt.mode, planned, tt.plan)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceFirewallZone() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages a firewall zone. Traffic between zones is filtered by `vyos_firewall_zone_policy`. `zone-policy zone` before VyOS 1.4.",
+		Path:        "firewall zone {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the zone.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"interfaces": {
+				Description: "Interfaces in the zone. `interface` before VyOS 1.5.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:     true,
+				ExactlyOneOf: []string{"interfaces", "local_zone"},
+			},
+			"local_zone": {
+				Description:  "The zone of the router itself, which has no interfaces.",
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: []string{"interfaces", "local_zone"},
+			},
+			"default_action": {
+				Description:      "`drop` or `reject` traffic from zones without a policy, `drop` if not set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"drop", "reject"}, false)),
+			},
+			"intra_zone_filtering": {
+				Description: "Filtering of traffic between interfaces of the zone, which is accepted if not set.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Description:      "`accept` or `drop` all traffic, exclusive with the rulesets.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"accept", "drop"}, false)),
+						},
+						"ipv4_ruleset": firewallRulesetSchema("IPv4"),
+						"ipv6_ruleset": firewallRulesetSchema("IPv6"),
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "interfaces", Node: "member interface"},
+			{Attr: "local_zone", Node: "local-zone"},
+			{Attr: "default_action", Node: "default-action"},
+			{Attr: "intra_zone_filtering", Node: "intra-zone-filtering", Fields: append([]configField{
+				{Attr: "action", Node: "action"},
+			}, firewallRulesetFields()...)},
+		},
+	}
+	return r.Resource()
+}
+
+// firewallRulesetSchema names a ruleset of the family, which must exist
+// when the resource is applied.
+func firewallRulesetSchema(family string) *schema.Schema {
+	return &schema.Schema{
+		Description: "Name of the " + family + " firewall ruleset filtering the traffic.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+}
+
+func firewallRulesetFields() []configField {
+	return []configField{
+		{Attr: "ipv4_ruleset", Node: "firewall name", Ref: "firewall ipv4 name"},
+		{Attr: "ipv6_ruleset", Node: "firewall ipv6-name", Ref: "firewall ipv6 name"},
+	}
+}
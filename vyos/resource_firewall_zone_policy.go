@@ -0,0 +1,36 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceFirewallZonePolicy() *schema.Resource {
+	ruleset := func(family string) *schema.Schema {
+		s := firewallRulesetSchema(family)
+		s.AtLeastOneOf = []string{"ipv4_ruleset", "ipv6_ruleset"}
+		return s
+	}
+
+	r := &configResource{
+		Description: "This resource filters traffic from one `vyos_firewall_zone` to another with firewall rulesets, which must exist when it is applied. Traffic without a policy gets the default action of the destination zone.",
+		Path:        "firewall zone {to_zone} from {from_zone}",
+		Schema: map[string]*schema.Schema{
+			"from_zone": {
+				Description: "Name of the zone the traffic comes from.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"to_zone": {
+				Description: "Name of the zone the traffic goes to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"ipv4_ruleset": ruleset("IPv4"),
+			"ipv6_ruleset": ruleset("IPv6"),
+		},
+		Fields: firewallRulesetFields(),
+	}
+	return r.Resource()
+}
@@ -0,0 +1,168 @@
+package vyos
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceBond() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+	stringSet := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional: true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a bonding interface. Each change is a single commit, so interfaces move in and out of the bond together.",
+		Path:         "interfaces bonding {name}",
+		InterfaceVRF: true,
+		Atomic:       true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `bond0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^bond\d+$`), "must be bond followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"addresses":   stringSet("Addresses with prefix length, or `dhcp` and `dhcpv6`."),
+			"mtu":         optional(schema.TypeInt, "MTU."),
+			"disable":     optional(schema.TypeBool, "Administratively disable the interface."),
+			"mode": {
+				Description:      "Bonding mode, e.g. `802.3ad` or `active-backup`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"802.3ad", "active-backup", "broadcast", "round-robin", "transmit-load-balance", "adaptive-load-balance", "xor-hash"}, false)),
+			},
+			"hash_policy": {
+				Description:      "Hash policy distributing traffic over the members, `layer2`, `layer2+3` or `layer3+4`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"layer2", "layer2+3", "layer3+4"}, false)),
+			},
+			"members": stringSet("Member interfaces. Interfaces joining the bond leave other bonds and bridges, and their addresses and VRF are deleted, in the same commit."),
+			"lacp_rate": {
+				Description:      "LACP rate in `802.3ad` mode, `slow` or `fast`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"slow", "fast"}, false)),
+			},
+			"min_links": {
+				Description:      "Members which must be up for the bond to be up in `802.3ad` mode.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 16)),
+			},
+			"primary": optional(schema.TypeString, "Preferred member in `active-backup` mode."),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "mode", Node: "mode"},
+			{Attr: "hash_policy", Node: "hash-policy"},
+			{Attr: "members", Node: "member interface"},
+			{Attr: "lacp_rate", Node: "lacp-rate"},
+			{Attr: "min_links", Node: "min-links"},
+			{Attr: "primary", Node: "primary"},
+		},
+		AtomicOps: memberOps(func(get func(string) interface{}) []string {
+			members := []string{}
+			for _, member := range configItems(get("members")) {
+				members = append(members, configString(member))
+			}
+			return members
+		}),
+	}
+	return r.Resource()
+}
+
+// memberOps returns the AtomicOps of a bond or bridge, given the member
+// names from the attribute values. Interfaces joining it leave other bonds
+// and bridges, and their addresses and VRF, which members can not have,
+// are deleted.
+func memberOps(members func(get func(string) interface{}) []string) func(ctx context.Context, p *ProviderClass, path string, old, new func(string) interface{}) ([]configOp, error) {
+	return func(ctx context.Context, p *ProviderClass, path string, old, new func(string) interface{}) ([]configOp, error) {
+		joining := map[string]interface{}{}
+		for _, member := range members(new) {
+			joining[member] = true
+		}
+		for _, member := range members(old) {
+			delete(joining, member)
+		}
+		if len(joining) == 0 {
+			return nil, nil
+		}
+
+		// Read the router instead of the cache, since the config may have
+		// changed earlier in the apply
+		c := *p.client
+		tree, err := c.Config.Show(ctx, "interfaces")
+		if err != nil {
+			return nil, err
+		}
+		interfaces := configMap(tree)
+
+		ops := []configOp{}
+		for _, kind := range []string{"bonding", "bridge"} {
+			owners := configMap(interfaces[kind])
+			for _, name := range sortedConfigKeys(owners) {
+				if strings.Join([]string{"interfaces", kind, name}, " ") == path {
+					continue
+				}
+				node, _ := lookupConfig(configMap(owners[name]), "member interface")
+				for _, member := range configStrings(node) {
+					if _, ok := joining[member]; ok {
+						ops = append(ops, configOp{Op: "delete", Path: []string{"interfaces", kind, name, "member", "interface", member}})
+					}
+				}
+			}
+		}
+		for _, member := range sortedConfigKeys(joining) {
+			memberPath, config := interfaceConfig(interfaces, member)
+			for _, node := range []string{"address", "vrf"} {
+				if _, ok := config[node]; ok {
+					ops = append(ops, configOp{Op: "delete", Path: append(memberPath[:len(memberPath):len(memberPath)], node)})
+				}
+			}
+		}
+		return ops, nil
+	}
+}
+
+// interfaceConfig finds the config of an interface by name, e.g. "eth0"
+// or the VLAN "eth0.10", returning its path.
+func interfaceConfig(interfaces map[string]interface{}, name string) ([]string, map[string]interface{}) {
+	base, vlan, isVLAN := strings.Cut(name, ".")
+	for _, kind := range sortedConfigKeys(interfaces) {
+		config, ok := configMap(interfaces[kind])[base]
+		if !ok {
+			continue
+		}
+		if !isVLAN {
+			return []string{"interfaces", kind, base}, configMap(config)
+		}
+		if vif, ok := configMap(configMap(config)["vif"])[vlan]; ok {
+			return []string{"interfaces", kind, base, "vif", vlan}, configMap(vif)
+		}
+	}
+	return nil, map[string]interface{}{}
+}
@@ -0,0 +1,110 @@
+package vyos
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceBridge() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a bridge interface. Each change is a single commit, so interfaces move in and out of the bridge together.",
+		Path:         "interfaces bridge {name}",
+		InterfaceVRF: true,
+		Atomic:       true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `br0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^br\d+$`), "must be br followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"addresses": {
+				Description: "Addresses with prefix length, or `dhcp` and `dhcpv6`.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"mtu":     optional(schema.TypeInt, "MTU."),
+			"disable": optional(schema.TypeBool, "Administratively disable the interface."),
+			"member": {
+				Description: "Member interfaces. Interfaces joining the bridge leave other bonds and bridges, and their addresses and VRF are deleted, in the same commit.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"interface": {
+							Description: "Interface name.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"priority": optional(schema.TypeInt, "STP port priority."),
+						"cost":     optional(schema.TypeInt, "STP path cost."),
+						"native_vlan": {
+							Description:      "VLAN of untagged traffic, with `enable_vlan`.",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 4094)),
+						},
+						"allowed_vlans": {
+							Description: "VLANs or ranges of them allowed on the member, e.g. `10-20`, with `enable_vlan`.",
+							Type:        schema.TypeSet,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"stp":              optional(schema.TypeBool, "Enable the spanning tree protocol."),
+			"priority":         optional(schema.TypeInt, "STP bridge priority."),
+			"forwarding_delay": optional(schema.TypeInt, "STP forwarding delay in seconds."),
+			"hello_time":       optional(schema.TypeInt, "STP hello time in seconds."),
+			"max_age":          optional(schema.TypeInt, "STP max age in seconds."),
+			"aging":            optional(schema.TypeInt, "Seconds until learned MAC addresses are forgotten."),
+			"enable_vlan":      optional(schema.TypeBool, "Make the bridge VLAN aware."),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "member", Node: "member interface", Key: "interface", Fields: []configField{
+				{Attr: "priority", Node: "priority"},
+				{Attr: "cost", Node: "cost"},
+				{Attr: "native_vlan", Node: "native-vlan"},
+				{Attr: "allowed_vlans", Node: "allowed-vlan"},
+			}},
+			{Attr: "stp", Node: "stp"},
+			{Attr: "priority", Node: "priority"},
+			{Attr: "forwarding_delay", Node: "forwarding-delay"},
+			{Attr: "hello_time", Node: "hello-time"},
+			{Attr: "max_age", Node: "max-age"},
+			{Attr: "aging", Node: "aging"},
+			{Attr: "enable_vlan", Node: "enable-vlan"},
+		},
+		AtomicOps: memberOps(func(get func(string) interface{}) []string {
+			members := []string{}
+			for _, member := range configItems(get("member")) {
+				block, _ := member.(map[string]interface{})
+				members = append(members, configString(block["interface"]))
+			}
+			return members
+		}),
+	}
+	return r.Resource()
+}
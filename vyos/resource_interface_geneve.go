@@ -0,0 +1,57 @@
+package vyos
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceGENEVE() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a GENEVE interface. It can be imported by its interface name.",
+		Path:         "interfaces geneve {name}",
+		InterfaceVRF: true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `gnv0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^gnv\d+$`), "must be gnv followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"addresses":   tunnelAddressesSchema(),
+			"mtu":         optional(schema.TypeInt, "MTU."),
+			"disable":     optional(schema.TypeBool, "Administratively disable the interface."),
+			"vni": {
+				Description:      "GENEVE network identifier.",
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 16777214)),
+			},
+			"remote": {
+				Description: "Remote address of the tunnel.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "vni", Node: "vni"},
+			{Attr: "remote", Node: "remote"},
+		},
+	}
+	return r.Resource()
+}
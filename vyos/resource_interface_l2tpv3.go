@@ -0,0 +1,91 @@
+package vyos
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceL2TPv3() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+	id := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description:      description,
+			Type:             schema.TypeInt,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+		}
+	}
+	port := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description:      description,
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a static L2TPv3 pseudowire interface. It can be imported by its interface name.",
+		Path:         "interfaces l2tpv3 {name}",
+		InterfaceVRF: true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `l2tpeth0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^l2tpeth\d+$`), "must be l2tpeth followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"addresses":   tunnelAddressesSchema(),
+			"mtu":         optional(schema.TypeInt, "MTU."),
+			"disable":     optional(schema.TypeBool, "Administratively disable the interface."),
+			"encapsulation": {
+				Description:      "`udp` or `ip`, `udp` by default.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"udp", "ip"}, false)),
+			},
+			"source_address": {
+				Description: "Local address of the tunnel.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"remote": {
+				Description: "Remote address of the tunnel.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"tunnel_id":        id("Local tunnel ID."),
+			"peer_tunnel_id":   id("Tunnel ID of the peer."),
+			"session_id":       id("Local session ID."),
+			"peer_session_id":  id("Session ID of the peer."),
+			"source_port":      port("Local UDP port, with `udp` encapsulation."),
+			"destination_port": port("UDP port of the peer, with `udp` encapsulation."),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "encapsulation", Node: "encapsulation"},
+			{Attr: "source_address", Node: "source-address"},
+			{Attr: "remote", Node: "remote"},
+			{Attr: "tunnel_id", Node: "tunnel-id"},
+			{Attr: "peer_tunnel_id", Node: "peer-tunnel-id"},
+			{Attr: "session_id", Node: "session-id"},
+			{Attr: "peer_session_id", Node: "peer-session-id"},
+			{Attr: "source_port", Node: "source-port"},
+			{Attr: "destination_port", Node: "destination-port"},
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,335 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceOpenVPN() *schema.Resource {
+	stringSet := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional: true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages an OpenVPN interface in `site-to-site`, `server` or `client` mode. TLS settings refer to PKI entries by name, which requires VyOS 1.4 or later.",
+		Path:         "interfaces openvpn {name}",
+		InterfaceVRF: true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `vtun0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^vtun\d+$`), "must be vtun followed by a number")),
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"mode": {
+				Description:      "`site-to-site`, `server` or `client`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"site-to-site", "server", "client"}, false)),
+			},
+			"protocol": {
+				Description:      "`udp`, `tcp-passive` or `tcp-active`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"udp", "tcp-passive", "tcp-active"}, false)),
+			},
+			"device_type": {
+				Description:      "`tun` or `tap`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"tun", "tap"}, false)),
+			},
+			"local_host": {
+				Description: "Local address to listen on.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"local_port": {
+				Description:      "Local port.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+			},
+			"remote_host": stringSet("Remote hosts to connect to, required in `client` mode."),
+			"remote_port": {
+				Description:      "Remote port.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+			},
+			"persistent_tunnel": {
+				Description: "Keep the interface up while the connection is down.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"local_address": {
+				Description: "Tunnel addresses of this side in `site-to-site` mode.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Description: "Address.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"subnet_mask": {
+							Description: "Subnet mask, for `tap` devices.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"remote_address": stringSet("Tunnel addresses of the remote side in `site-to-site` mode."),
+			"shared_secret_key": {
+				Description: "Name of the `pki openvpn shared-secret` for `site-to-site` mode without TLS.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"cipher": {
+				Description: "Cipher of static key connections, e.g. `aes256`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"data_ciphers": stringSet("Ciphers negotiated for TLS connections, e.g. `aes256gcm`. `ncp-ciphers` before VyOS 1.4."),
+			"hash": {
+				Description: "Hash algorithm, e.g. `sha256`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tls": {
+				Description: "TLS settings, required in `server` and `client` mode.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ca_certificates": stringSet("Names of the `vyos_pki_ca`s to verify the peer with."),
+						"certificate": {
+							Description: "Name of the `vyos_pki_certificate` of this side.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"dh_params": {
+							Description: "Name of the `pki dh` parameters, for `server` mode.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"auth_key": {
+							Description: "Name of the `pki openvpn shared-secret` used for tls-auth.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"crypt_key": {
+							Description: "Name of the `pki openvpn shared-secret` used for tls-crypt.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"role": {
+							Description:      "TLS role in `site-to-site` mode, `active` or `passive`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"active", "passive"}, false)),
+						},
+						"tls_version_min": {
+							Description: "Minimum TLS version, e.g. `1.2`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"server": {
+				Description: "Server settings, required in `server` mode.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet": stringSet("Subnets clients get their addresses from."),
+						"topology": {
+							Description:      "`subnet`, `net30` or `point-to-point`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"subnet", "net30", "point-to-point"}, false)),
+						},
+						"max_connections": {
+							Description: "Maximum number of connected clients.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"push_route":  stringSet("Routes pushed to the clients."),
+						"name_server": stringSet("DNS servers pushed to the clients."),
+						"domain_name": {
+							Description: "DNS domain pushed to the clients.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"client_ip_pool": {
+							Description: "Pool of client addresses.",
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": {
+										Description: "First address.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"stop": {
+										Description: "Last address.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"subnet_mask": {
+										Description: "Subnet mask pushed to the clients.",
+										Type:        schema.TypeString,
+										Optional:    true,
+									},
+								},
+							},
+							Optional: true,
+						},
+						"client": {
+							Description: "Settings of individual clients, by certificate common name.",
+							Type:        schema.TypeSet,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "Common name of the client certificate.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"ip":         stringSet("Fixed addresses of the client."),
+									"subnet":     stringSet("Subnets routed to the client."),
+									"push_route": stringSet("Routes pushed to the client."),
+									"disable": {
+										Description: "Refuse connections of the client.",
+										Type:        schema.TypeBool,
+										Optional:    true,
+									},
+								},
+							},
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "mode", Node: "mode"},
+			{Attr: "protocol", Node: "protocol"},
+			{Attr: "device_type", Node: "device-type"},
+			{Attr: "local_host", Node: "local-host"},
+			{Attr: "local_port", Node: "local-port"},
+			{Attr: "remote_host", Node: "remote-host"},
+			{Attr: "remote_port", Node: "remote-port"},
+			{Attr: "persistent_tunnel", Node: "persistent-tunnel"},
+			{Attr: "local_address", Node: "local-address", Key: "address", Fields: []configField{
+				{Attr: "subnet_mask", Node: "subnet-mask"},
+			}},
+			{Attr: "remote_address", Node: "remote-address"},
+			{Attr: "shared_secret_key", Node: "shared-secret-key", Ref: "pki openvpn shared-secret"},
+			{Attr: "cipher", Node: "encryption cipher"},
+			{Attr: "data_ciphers", Node: "encryption data-ciphers"},
+			{Attr: "hash", Node: "hash"},
+			{Attr: "tls", Node: "tls", Fields: []configField{
+				{Attr: "ca_certificates", Node: "ca-certificate", Ref: "pki ca"},
+				{Attr: "certificate", Node: "certificate", Ref: "pki certificate"},
+				{Attr: "dh_params", Node: "dh-params", Ref: "pki dh"},
+				{Attr: "auth_key", Node: "auth-key", Ref: "pki openvpn shared-secret"},
+				{Attr: "crypt_key", Node: "crypt-key", Ref: "pki openvpn shared-secret"},
+				{Attr: "role", Node: "role"},
+				{Attr: "tls_version_min", Node: "tls-version-min"},
+			}},
+			{Attr: "server", Node: "server", Fields: []configField{
+				{Attr: "subnet", Node: "subnet"},
+				{Attr: "topology", Node: "topology"},
+				{Attr: "max_connections", Node: "max-connections"},
+				{Attr: "push_route", Node: "push-route"},
+				{Attr: "name_server", Node: "name-server"},
+				{Attr: "domain_name", Node: "domain-name"},
+				{Attr: "client_ip_pool", Node: "client-ip-pool", Fields: []configField{
+					{Attr: "start", Node: "start"},
+					{Attr: "stop", Node: "stop"},
+					{Attr: "subnet_mask", Node: "subnet-mask"},
+				}},
+				{Attr: "client", Node: "client", Key: "name", Fields: []configField{
+					{Attr: "ip", Node: "ip"},
+					{Attr: "subnet", Node: "subnet"},
+					{Attr: "push_route", Node: "push-route"},
+					{Attr: "disable", Node: "disable"},
+				}},
+			}},
+		},
+		CustomizeDiff: openvpnCustomizeDiff,
+	}
+	return r.Resource()
+}
+
+// openvpnCustomizeDiff checks the attributes each mode requires or rejects.
+func openvpnCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("mode") {
+		return nil
+	}
+	mode := d.Get("mode").(string)
+
+	// Unknown values may be set for requirements, but not for rejections,
+	// which are checked again once they are known
+	known := func(attr string) bool {
+		_, ok := d.GetOk(attr)
+		return ok && d.NewValueKnown(attr)
+	}
+	set := func(attr string) bool {
+		return !d.NewValueKnown(attr) || known(attr)
+	}
+
+	var required, rejected []string
+	switch mode {
+	case "site-to-site":
+		if !set("shared_secret_key") && !set("tls") {
+			return fmt.Errorf("site-to-site mode requires `shared_secret_key` or `tls`")
+		}
+		rejected = []string{"server"}
+	case "server":
+		required = []string{"tls.0.ca_certificates", "tls.0.certificate"}
+		if d.NewValueKnown("device_type") && d.Get("device_type").(string) != "tap" {
+			required = append(required, "server.0.subnet")
+		}
+		rejected = []string{"remote_host", "shared_secret_key"}
+	case "client":
+		required = []string{"remote_host", "tls.0.ca_certificates"}
+		rejected = []string{"server", "shared_secret_key"}
+	}
+
+	for _, attr := range required {
+		if !set(attr) {
+			return fmt.Errorf("%s mode requires `%s`", mode, attr)
+		}
+	}
+	for _, attr := range rejected {
+		if known(attr) {
+			return fmt.Errorf("`%s` is not supported in %s mode", attr, mode)
+		}
+	}
+	return nil
+}
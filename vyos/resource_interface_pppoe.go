@@ -0,0 +1,136 @@
+package vyos
+
+import (
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfacePPPoE() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a PPPoE client interface. The session state is available from the `vyos_pppoe_session` data source.",
+		Path:         "interfaces pppoe {name}",
+		InterfaceVRF: true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `pppoe0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^pppoe\d+$`), "must be pppoe followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"disable":     optional(schema.TypeBool, "Administratively disable the interface."),
+			"source_interface": {
+				Description: "Interface the PPPoE session runs over, e.g. `eth0` or `eth0.7`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"username": {
+				Description: "Authentication user name. `authentication user` before VyOS 1.4.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"password": {
+				Description: "Authentication password.",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+			"default_route": {
+				Description: "Install a default route through the session. Disabling it sets `default-route none` before VyOS 1.4.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"default_route_distance": {
+				Description:      "Distance of the default route. Requires VyOS 1.4 or later.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 255)),
+			},
+			"no_peer_dns":         optional(schema.TypeBool, "Ignore DNS servers announced by the peer."),
+			"mtu":                 optional(schema.TypeInt, "MTU, 1492 by default."),
+			"connect_on_demand":   optional(schema.TypeBool, "Only connect when there is traffic."),
+			"idle_timeout":        optional(schema.TypeInt, "Seconds without traffic until an on-demand session disconnects."),
+			"service_name":        optional(schema.TypeString, "Service name to request from the access concentrator."),
+			"access_concentrator": optional(schema.TypeString, "Name of the access concentrator to connect to."),
+			"ipv6_autoconf":       optional(schema.TypeBool, "Configure an IPv6 address with SLAAC."),
+			"dhcpv6_prefix_delegation": {
+				Description: "Prefixes to request with DHCPv6 and assign to other interfaces.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Identity association ID.",
+							Type:        schema.TypeInt,
+							Required:    true,
+						},
+						"length": optional(schema.TypeInt, "Requested prefix length."),
+						"interface": {
+							Description: "Interfaces to assign subnets of the prefix to.",
+							Type:        schema.TypeSet,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Description: "Interface name.",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+									"sla_id":  optional(schema.TypeInt, "Subnet of the prefix to assign."),
+									"address": optional(schema.TypeString, "Interface ID of the address in the subnet, e.g. `1`."),
+								},
+							},
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "source_interface", Node: "source-interface"},
+			{Attr: "username", Node: "authentication username"},
+			{Attr: "password", Node: "authentication password"},
+			{Attr: "default_route_distance", Node: "default-route-distance"},
+			{Attr: "no_peer_dns", Node: "no-peer-dns"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "connect_on_demand", Node: "connect-on-demand"},
+			{Attr: "idle_timeout", Node: "idle-timeout"},
+			{Attr: "service_name", Node: "service-name"},
+			{Attr: "access_concentrator", Node: "access-concentrator"},
+			{Attr: "ipv6_autoconf", Node: "ipv6 address autoconf"},
+			{Attr: "dhcpv6_prefix_delegation", Node: "dhcpv6-options pd", Key: "id", Fields: []configField{
+				{Attr: "length", Node: "length"},
+				{Attr: "interface", Node: "interface", Key: "name", Fields: []configField{
+					{Attr: "sla_id", Node: "sla-id"},
+					{Attr: "address", Node: "address"},
+				}},
+			}},
+		},
+		// 1.4 installs the default route unless no-default-route is set,
+		// which is "default-route none" before
+		Expand: func(get func(string) interface{}) []configCommand {
+			if v, _ := get("default_route").(bool); !v {
+				return []configCommand{{path: []string{"no-default-route"}, owner: 1}}
+			}
+			return nil
+		},
+		Flatten: func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+			_, noDefaultRoute := lookupConfig(tree, "no-default-route")
+			return map[string]interface{}{"default_route": !noDefaultRoute}
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,112 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceTunnel() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a GRE or IP-in-IP tunnel interface. It can be imported by its interface name.",
+		Path:         "interfaces tunnel {name}",
+		InterfaceVRF: true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `tun0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^tun\d+$`), "must be tun followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"addresses":   tunnelAddressesSchema(),
+			"mtu":         optional(schema.TypeInt, "MTU."),
+			"disable":     optional(schema.TypeBool, "Administratively disable the interface."),
+			"encapsulation": {
+				Description:      "Encapsulation, e.g. `gre`, `gretap`, `ip6gre` or `ipip`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(tunnelEncapsulations, false)),
+			},
+			"source_address":   optional(schema.TypeString, "Local address of the tunnel."),
+			"source_interface": optional(schema.TypeString, "Interface the tunnel is bound to."),
+			"remote":           optional(schema.TypeString, "Remote address of the tunnel. Only `gre` tunnels may omit it, for multipoint GRE."),
+			"key": {
+				Description:      "GRE key, which tells tunnels between the same addresses apart.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "encapsulation", Node: "encapsulation"},
+			{Attr: "source_address", Node: "source-address"},
+			{Attr: "source_interface", Node: "source-interface"},
+			{Attr: "remote", Node: "remote"},
+			{Attr: "key", Node: "parameters ip key"},
+		},
+		CustomizeDiff: tunnelCustomizeDiff,
+	}
+	return r.Resource()
+}
+
+var tunnelEncapsulations = []string{"gre", "gretap", "ip6gre", "ip6gretap", "ipip", "ipip6", "ip6ip6", "sit"}
+
+func tunnelAddressesSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Addresses with prefix length.",
+		Type:        schema.TypeSet,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Optional: true,
+	}
+}
+
+// plannedSet reports whether attr is set in the plan, assuming unknown
+// values will be.
+func plannedSet(d *schema.ResourceDiff, attr string) bool {
+	if !d.NewValueKnown(attr) {
+		return true
+	}
+	_, ok := d.GetOk(attr)
+	return ok
+}
+
+func tunnelCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("encapsulation") {
+		return nil
+	}
+	encapsulation := d.Get("encapsulation").(string)
+
+	if !plannedSet(d, "source_address") && !plannedSet(d, "source_interface") {
+		return fmt.Errorf("`source_address` or `source_interface` is required")
+	}
+	if encapsulation != "gre" && !plannedSet(d, "remote") {
+		return fmt.Errorf("%s tunnels require `remote`", encapsulation)
+	}
+	switch encapsulation {
+	case "gre", "gretap", "ip6gre", "ip6gretap":
+	default:
+		if plannedSet(d, "key") {
+			return fmt.Errorf("`key` is only supported by GRE tunnels, not %s", encapsulation)
+		}
+	}
+	return nil
+}
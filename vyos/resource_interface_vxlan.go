@@ -0,0 +1,89 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceInterfaceVXLAN() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description:  "This resource manages a VXLAN interface, with unicast remotes or a multicast group. It can be imported by its interface name.",
+		Path:         "interfaces vxlan {name}",
+		InterfaceVRF: true,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description:      "Interface name, e.g. `vxlan0`.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^vxlan\d+$`), "must be vxlan followed by a number")),
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"addresses":   tunnelAddressesSchema(),
+			"mtu":         optional(schema.TypeInt, "MTU."),
+			"disable":     optional(schema.TypeBool, "Administratively disable the interface."),
+			"vni": {
+				Description:      "VXLAN network identifier.",
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 16777214)),
+			},
+			"remote": {
+				Description: "Unicast remote addresses. VyOS before 1.4 supports a single one.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"group":            optional(schema.TypeString, "Multicast group, which requires `source_interface`."),
+			"source_address":   optional(schema.TypeString, "Local address of the tunnel."),
+			"source_interface": optional(schema.TypeString, "Interface the tunnel is bound to."),
+			"port": {
+				Description:      "UDP port, 8472 by default.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "mtu", Node: "mtu"},
+			{Attr: "disable", Node: "disable"},
+			{Attr: "vni", Node: "vni"},
+			{Attr: "remote", Node: "remote"},
+			{Attr: "group", Node: "group"},
+			{Attr: "source_address", Node: "source-address"},
+			{Attr: "source_interface", Node: "source-interface"},
+			{Attr: "port", Node: "port"},
+		},
+		CustomizeDiff: vxlanCustomizeDiff,
+	}
+	return r.Resource()
+}
+
+func vxlanCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	remote, group := plannedSet(d, "remote"), plannedSet(d, "group")
+	switch {
+	case remote && group && d.NewValueKnown("remote") && d.NewValueKnown("group"):
+		return fmt.Errorf("`remote` and `group` are mutually exclusive")
+	case !remote && !group:
+		return fmt.Errorf("`remote` or `group` is required")
+	case group && d.NewValueKnown("group") && !plannedSet(d, "source_interface"):
+		return fmt.Errorf("`group` requires `source_interface`")
+	}
+	return nil
+}
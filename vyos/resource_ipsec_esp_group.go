@@ -0,0 +1,45 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIPsecESPGroup() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages an IPsec ESP (phase 2) group, referenced by site-to-site peers and their tunnels.",
+		Path:        "vpn ipsec esp-group {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Group name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"mode": {
+				Description:      "ESP mode, `tunnel` or `transport`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"tunnel", "transport"}, false)),
+			},
+			"lifetime": {
+				Description: "IPsec SA lifetime in seconds.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"pfs": {
+				Description: "Perfect forward secrecy, `enable`, `disable` or a Diffie-Hellman group such as `dh-group14`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"proposal": ipsecProposalSchema(false),
+		},
+		Fields: []configField{
+			{Attr: "mode", Node: "mode"},
+			{Attr: "lifetime", Node: "lifetime"},
+			{Attr: "pfs", Node: "pfs"},
+			ipsecProposalField(false),
+		},
+	}
+	return r.Resource()
+}
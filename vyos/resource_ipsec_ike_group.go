@@ -0,0 +1,141 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIPsecIKEGroup() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages an IPsec IKE (phase 1) group, referenced by site-to-site peers.",
+		Path:        "vpn ipsec ike-group {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Group name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"key_exchange": {
+				Description:      "IKE version, `ikev1` or `ikev2`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"ikev1", "ikev2"}, false)),
+			},
+			"mode": {
+				Description:      "IKEv1 phase 1 mode, `main` or `aggressive`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"main", "aggressive"}, false)),
+			},
+			"lifetime": {
+				Description: "IKE SA lifetime in seconds.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"close_action": {
+				Description:      "Action when the peer closes the connection, `none`, `trap` or `start`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"none", "trap", "start"}, false)),
+			},
+			"dead_peer_detection": {
+				Description: "Dead peer detection.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Description:      "Action when the peer is dead, `hold`, `clear`, `trap` or `restart`.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"hold", "clear", "trap", "restart"}, false)),
+						},
+						"interval": {
+							Description: "Seconds between keep-alive requests.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"timeout": {
+							Description: "Seconds without response before the peer is considered dead.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"proposal": ipsecProposalSchema(true),
+		},
+		Fields: []configField{
+			{Attr: "key_exchange", Node: "key-exchange"},
+			{Attr: "mode", Node: "mode"},
+			{Attr: "lifetime", Node: "lifetime"},
+			{Attr: "close_action", Node: "close-action"},
+			{Attr: "dead_peer_detection", Node: "dead-peer-detection", Fields: []configField{
+				{Attr: "action", Node: "action"},
+				{Attr: "interval", Node: "interval"},
+				{Attr: "timeout", Node: "timeout"},
+			}},
+			ipsecProposalField(true),
+		},
+	}
+	return r.Resource()
+}
+
+// ipsecProposalSchema is the proposal set of IKE groups, with dh_group and
+// prf, and ESP groups.
+func ipsecProposalSchema(ike bool) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"number": {
+			Description: "Proposal number, lower numbers are preferred.",
+			Type:        schema.TypeInt,
+			Required:    true,
+		},
+		"encryption": {
+			Description: "Encryption algorithm, e.g. `aes256gcm128`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+		"hash": {
+			Description: "Hash algorithm, e.g. `sha256`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		},
+	}
+	if ike {
+		s["dh_group"] = &schema.Schema{
+			Description: "Diffie-Hellman group, e.g. `14`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+		s["prf"] = &schema.Schema{
+			Description: "Pseudo-random function, e.g. `prfsha256`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+	}
+
+	return &schema.Schema{
+		Description: "Proposals offered to the peer.",
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+		Required: true,
+	}
+}
+
+func ipsecProposalField(ike bool) configField {
+	field := configField{Attr: "proposal", Node: "proposal", Key: "number", Fields: []configField{
+		{Attr: "encryption", Node: "encryption"},
+		{Attr: "hash", Node: "hash"},
+	}}
+	if ike {
+		field.Fields = append(field.Fields,
+			configField{Attr: "dh_group", Node: "dh-group"},
+			configField{Attr: "prf", Node: "prf"},
+		)
+	}
+	return field
+}
@@ -0,0 +1,39 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceIPsecPSK() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages an IPsec pre-shared key, which VyOS 1.4 and later selects by the local and remote IDs of a peer.",
+		Path:        "vpn ipsec authentication psk {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Key name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"ids": {
+				Description: "Local and remote IDs or addresses the key is used for.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required: true,
+			},
+			"secret": {
+				Description: "The pre-shared key.",
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "ids", Node: "id"},
+			{Attr: "secret", Node: "secret"},
+		},
+	}
+	return r.Resource()
+}
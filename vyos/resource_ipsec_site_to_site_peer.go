@@ -0,0 +1,211 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIPsecSiteToSitePeer() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages an IPsec site-to-site peer, with policy based `tunnel`s or a route based `vti`. The referenced groups, certificates and VTI interface must exist when it is applied.",
+		Path:        "vpn ipsec site-to-site peer {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Peer name. Before VyOS 1.4 this is the remote address.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"local_address": {
+				Description: "Local address, or `any`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"remote_address": {
+				Description: "Remote address, host name or `any`. Requires VyOS 1.4 or later.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"connection_type": {
+				Description:      "`initiate` the connection, or only respond to the peer, which is `none` since VyOS 1.4 and `respond` before. `trap` initiates the connection on matching traffic, and requires VyOS 1.4 or later.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"initiate", "respond", "trap", "none"}, false)),
+			},
+			"ike_group": {
+				Description: "Name of the `vyos_ipsec_ike_group`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"default_esp_group": {
+				Description: "Name of the `vyos_ipsec_esp_group` used by tunnels without their own.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"authentication": {
+				Description: "Authentication of the peers.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mode": {
+							Description:      "`pre-shared-secret`, `x509` or `rsa`.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"pre-shared-secret", "x509", "rsa"}, false)),
+						},
+						"local_id": {
+							Description: "Local ID, which selects the `vyos_ipsec_psk` on VyOS 1.4 and later.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"remote_id": {
+							Description: "Remote ID, which selects the `vyos_ipsec_psk` on VyOS 1.4 and later.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"pre_shared_secret": {
+							Description: "Pre-shared key. Only before VyOS 1.4, use `vyos_ipsec_psk` otherwise.",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"ca_certificate": {
+							Description: "Name of the `vyos_pki_ca` of the peer certificate in `x509` mode.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"certificate": {
+							Description: "Name of the local `vyos_pki_certificate` in `x509` mode.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Required: true,
+			},
+			"tunnel": {
+				Description: "Policy based tunnels.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"number": {
+							Description: "Tunnel number.",
+							Type:        schema.TypeInt,
+							Required:    true,
+						},
+						"esp_group": {
+							Description: "Name of the `vyos_ipsec_esp_group`, `default_esp_group` if not set.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"local_prefix": {
+							Description: "Local prefixes.",
+							Type:        schema.TypeSet,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+						"remote_prefix": {
+							Description: "Remote prefixes.",
+							Type:        schema.TypeSet,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional: true,
+						},
+						"protocol": {
+							Description: "Protocol to encrypt, all by default.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional:      true,
+				ConflictsWith: []string{"vti"},
+			},
+			"vti": {
+				Description: "Route based VPN through a VTI interface.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bind": {
+							Description: "VTI interface, e.g. `vti0`.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"esp_group": {
+							Description: "Name of the `vyos_ipsec_esp_group`, `default_esp_group` if not set.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "local_address", Node: "local-address"},
+			{Attr: "remote_address", Node: "remote-address"},
+			{Attr: "connection_type", Node: "connection-type"},
+			{Attr: "ike_group", Node: "ike-group", Ref: "vpn ipsec ike-group"},
+			{Attr: "default_esp_group", Node: "default-esp-group", Ref: "vpn ipsec esp-group"},
+			{Attr: "authentication", Node: "authentication", Fields: []configField{
+				{Attr: "mode", Node: "mode"},
+				{Attr: "local_id", Node: "local-id"},
+				{Attr: "remote_id", Node: "remote-id"},
+				{Attr: "pre_shared_secret", Node: "pre-shared-secret"},
+				{Attr: "ca_certificate", Node: "x509 ca-certificate", Ref: "pki ca"},
+				{Attr: "certificate", Node: "x509 certificate", Ref: "pki certificate"},
+			}},
+			{Attr: "tunnel", Node: "tunnel", Key: "number", Fields: []configField{
+				{Attr: "esp_group", Node: "esp-group", Ref: "vpn ipsec esp-group"},
+				{Attr: "local_prefix", Node: "local prefix"},
+				{Attr: "remote_prefix", Node: "remote prefix"},
+				{Attr: "protocol", Node: "protocol"},
+			}},
+			{Attr: "vti", Node: "vti", Fields: []configField{
+				{Attr: "bind", Node: "bind", Ref: "interfaces vti"},
+				{Attr: "esp_group", Node: "esp-group", Ref: "vpn ipsec esp-group"},
+			}},
+		},
+		CustomizeDiff: ipsecPeerCustomizeDiff,
+	}
+	return r.Resource()
+}
+
+// ipsecPeerCustomizeDiff rejects connection types of other releases, as
+// VyOS 1.4 replaced `respond` with `none` and added `trap`.
+func ipsecPeerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("device") {
+		return nil
+	}
+	p, err := providerDevice(m, d)
+	if err != nil || p.versionErr != nil {
+		// Reported when the paths are translated
+		return nil
+	}
+
+	switch d.Get("connection_type").(string) {
+	case "respond":
+		if !p.version.before(vyos14) {
+			return fmt.Errorf("`connection_type` `respond` is not available on VyOS %s, use `none` to only respond to the peer", p.version)
+		}
+	case "trap":
+		if p.version.before(vyos14) {
+			return fmt.Errorf("`connection_type` `trap` is not available on VyOS %s, it requires %s or later", p.version, vyos14)
+		}
+	}
+	return nil
+}
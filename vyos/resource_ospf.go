@@ -0,0 +1,203 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceOSPF() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages the global settings of OSPF or OSPFv3. It adopts the current config on create, and settings which are not set stay unmanaged. Areas and interfaces are managed by `vyos_ospf_area` and `vyos_ospf_interface`.",
+		Path:        "protocols {protocol}",
+		VRF:         true,
+		Shared:      true,
+		Schema: map[string]*schema.Schema{
+			"protocol": ospfProtocolSchema(),
+			"router_id": {
+				Description: "Router ID, an IPv4 address.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"abr_type": {
+				Description:      "ABR type, e.g. `cisco`. Not supported by OSPFv3.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"cisco", "ibm", "shortcut", "standard"}, false)),
+			},
+			"rfc1583_compatibility": {
+				Description: "Use the RFC 1583 route preference rules. Not supported by OSPFv3.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"reference_bandwidth": {
+				Description: "Reference bandwidth for interface costs in Mbit/s.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"log_adjacency_changes": {
+				Description: "Log adjacency state changes.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"passive_interface_default": {
+				Description: "Make all interfaces passive by default. Not supported by OSPFv3.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+			"default_information_originate": {
+				Description: "Originate a default route into the OSPF domain.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"always": {
+							Description: "Originate it even without a default route.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"metric": {
+							Description: "Metric of the route.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"metric_type": {
+							Description:      "External metric type, `1` or `2`.",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 2)),
+						},
+						"route_map": {
+							Description: "Route map applied to the route.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"redistribute": {
+				Description: "Routes of other protocols to redistribute.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"protocol": {
+							Description: "Source protocol, e.g. `connected`, `static` or `bgp`.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"metric": {
+							Description: "Metric of the routes.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"metric_type": {
+							Description:      "External metric type, `1` or `2`.",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 2)),
+						},
+						"route_map": {
+							Description: "Route map filtering the routes.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+				Computed: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "router_id", Node: "parameters router-id"},
+			{Attr: "abr_type", Node: "parameters abr-type"},
+			{Attr: "rfc1583_compatibility", Node: "parameters rfc1583-compatibility"},
+			{Attr: "reference_bandwidth", Node: "auto-cost reference-bandwidth"},
+			{Attr: "log_adjacency_changes", Node: "log-adjacency-changes"},
+			{Attr: "default_information_originate", Node: "default-information originate", Fields: []configField{
+				{Attr: "always", Node: "always"},
+				{Attr: "metric", Node: "metric"},
+				{Attr: "metric_type", Node: "metric-type"},
+				{Attr: "route_map", Node: "route-map", Ref: "policy route-map"},
+			}},
+			{Attr: "redistribute", Node: "redistribute", Key: "protocol", Fields: []configField{
+				{Attr: "metric", Node: "metric"},
+				{Attr: "metric_type", Node: "metric-type"},
+				{Attr: "route_map", Node: "route-map", Ref: "policy route-map"},
+			}},
+		},
+		// passive-interface takes the value "default" rather than being
+		// valueless
+		Expand: func(get func(string) interface{}) []configCommand {
+			if v, _ := get("passive_interface_default").(bool); v {
+				return []configCommand{{path: []string{"passive-interface"}, value: "default", owner: 1}}
+			}
+			return nil
+		},
+		Flatten: func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+			node, _ := lookupConfig(tree, "passive-interface")
+			for _, v := range configStrings(node) {
+				if v == "default" {
+					return map[string]interface{}{"passive_interface_default": true}
+				}
+			}
+			return map[string]interface{}{"passive_interface_default": false}
+		},
+		CustomizeDiff: ospfv3Unsupported("abr_type", "rfc1583_compatibility", "passive_interface_default"),
+	}
+	return r.Resource()
+}
+
+// ospfProtocolSchema selects between OSPF and OSPFv3, which the OSPF
+// resources share.
+func ospfProtocolSchema() *schema.Schema {
+	return &schema.Schema{
+		Description:      "`ospf` or `ospfv3`.",
+		Type:             schema.TypeString,
+		Optional:         true,
+		ForceNew:         true,
+		Default:          "ospf",
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"ospf", "ospfv3"}, false)),
+	}
+}
+
+// ospfv3Unsupported rejects configuring attrs for OSPFv3.
+func ospfv3Unsupported(attrs ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Get("protocol").(string) != "ospfv3" {
+			return nil
+		}
+		for _, attr := range attrs {
+			if v, _ := d.GetRawConfigAt(cty.GetAttrPath(attr)); configuredValue(v) {
+				return fmt.Errorf("`%s` is not supported by ospfv3", attr)
+			}
+		}
+		return nil
+	}
+}
+
+// configuredValue reports whether a raw config value is set to anything but
+// false or an empty collection. Unknown values count as set.
+func configuredValue(v cty.Value) bool {
+	switch {
+	case v.IsNull():
+		return false
+	case !v.IsKnown():
+		return true
+	case v.Type() == cty.Bool:
+		return v.True()
+	case v.CanIterateElements():
+		return v.LengthInt() > 0
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceOSPFArea() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages an OSPF or OSPFv3 area.",
+		Path:        "protocols {protocol} area {area_id}",
+		VRF:         true,
+		Schema: map[string]*schema.Schema{
+			"protocol": ospfProtocolSchema(),
+			"area_id": {
+				Description:      "Area ID, as a number or in dotted decimal notation.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^(\d+|\d+\.\d+\.\d+\.\d+)$`), "must be a number or an address")),
+			},
+			"area_type": {
+				Description:      "`normal`, `stub` or `nssa`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"normal", "stub", "nssa"}, false)),
+			},
+			"no_summary": {
+				Description: "Do not inject inter-area routes into a `stub` or `nssa` area.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"default_cost": {
+				Description: "Cost of the default route into a `stub` or `nssa` area. Not supported by OSPFv3.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"nssa_translate": {
+				Description:      "NSSA translator role, `always`, `candidate` or `never`. Not supported by OSPFv3.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"always", "candidate", "never"}, false)),
+			},
+			"networks": {
+				Description: "Networks whose interfaces are in the area. Not supported by OSPFv3, use `vyos_ospf_interface` instead.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"range": {
+				Description: "Ranges summarizing the routes of the area.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"prefix": {
+							Description: "Prefix of the range.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"cost": {
+							Description: "Cost of the summary route. Not supported by OSPFv3.",
+							Type:        schema.TypeInt,
+							Optional:    true,
+						},
+						"not_advertise": {
+							Description: "Suppress the routes of the range instead.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"substitute": {
+							Description: "Prefix to advertise instead. Not supported by OSPFv3.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+			},
+			"authentication": {
+				Description:      "Authentication of the area, `plaintext-password` or `md5`. Keys are set by `vyos_ospf_interface`. Not supported by OSPFv3.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"plaintext-password", "md5"}, false)),
+			},
+		},
+		Fields: []configField{
+			{Attr: "networks", Node: "network"},
+			{Attr: "range", Node: "range", Key: "prefix", Fields: []configField{
+				{Attr: "cost", Node: "cost"},
+				{Attr: "not_advertise", Node: "not-advertise"},
+				{Attr: "substitute", Node: "substitute"},
+			}},
+			{Attr: "authentication", Node: "authentication"},
+		},
+		Expand:        expandOSPFAreaType,
+		Flatten:       flattenOSPFAreaType,
+		CustomizeDiff: ospfAreaCustomizeDiff,
+	}
+	return r.Resource()
+}
+
+// The area type is a node named by the type, holding its options.
+func expandOSPFAreaType(get func(string) interface{}) []configCommand {
+	areaType := configString(get("area_type"))
+	if areaType == "" {
+		return nil
+	}
+
+	base := []string{"area-type", areaType}
+	commands := []configCommand{}
+	if v, _ := get("no_summary").(bool); v {
+		commands = append(commands, configCommand{path: append(base[:2:2], "no-summary"), owner: 1})
+	}
+	if v := configString(get("default_cost")); v != "" {
+		commands = append(commands, configCommand{path: append(base[:2:2], "default-cost"), value: v, owner: 1})
+	}
+	if v := configString(get("nssa_translate")); v != "" {
+		commands = append(commands, configCommand{path: append(base[:2:2], "translate"), value: v, owner: 1})
+	}
+	if len(commands) == 0 {
+		commands = append(commands, configCommand{path: base, owner: 1})
+	}
+	return commands
+}
+
+func flattenOSPFAreaType(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+	values := map[string]interface{}{
+		"area_type":      nil,
+		"no_summary":     false,
+		"default_cost":   nil,
+		"nssa_translate": nil,
+	}
+	node, _ := lookupConfig(tree, "area-type")
+	types := configMap(node)
+	for _, areaType := range sortedConfigKeys(types) {
+		options := configMap(types[areaType])
+		_, values["no_summary"] = options["no-summary"]
+		if v := configStrings(options["default-cost"]); len(v) > 0 {
+			values["default_cost"], _ = configScalar(&schema.Schema{Type: schema.TypeInt}, v[0])
+		}
+		if v := configStrings(options["translate"]); len(v) > 0 {
+			values["nssa_translate"] = v[0]
+		}
+		values["area_type"] = areaType
+		break
+	}
+	return values
+}
+
+func ospfAreaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	areaType := d.Get("area_type").(string)
+	if d.NewValueKnown("area_type") {
+		if areaType != "stub" && areaType != "nssa" {
+			for _, attr := range []string{"no_summary", "default_cost"} {
+				if _, ok := d.GetOk(attr); ok {
+					return fmt.Errorf("`%s` requires a `stub` or `nssa` area_type", attr)
+				}
+			}
+		}
+		if _, ok := d.GetOk("nssa_translate"); ok && areaType != "nssa" {
+			return fmt.Errorf("`nssa_translate` requires the `nssa` area_type")
+		}
+	}
+	return ospfv3Unsupported("default_cost", "nssa_translate", "networks", "authentication")(ctx, d, m)
+}
@@ -0,0 +1,128 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceOSPFInterface() *schema.Resource {
+	interval := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description:      description,
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 65535)),
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages the OSPF or OSPFv3 settings of an interface. Requires VyOS 1.4 or later, earlier releases configure them below the interface.",
+		Path:        "protocols {protocol} interface {name}",
+		VRF:         true,
+		Schema: map[string]*schema.Schema{
+			"protocol": ospfProtocolSchema(),
+			"name": {
+				Description: "Interface name, e.g. `eth0`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"area": {
+				Description: "Area the interface is in, instead of a `vyos_ospf_area` network.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"cost": {
+				Description:      "Cost of the interface.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 65535)),
+			},
+			"priority": {
+				Description:      "Priority in designated router elections.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 255)),
+			},
+			"hello_interval":      interval("Seconds between hello packets."),
+			"dead_interval":       interval("Seconds without hello packets until a neighbor is down."),
+			"retransmit_interval": interval("Seconds between retransmissions."),
+			"transmit_delay":      interval("Seconds added to the age of sent link state updates."),
+			"network": {
+				Description:      "Network type, e.g. `broadcast` or `point-to-point`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"broadcast", "non-broadcast", "point-to-multipoint", "point-to-point"}, false)),
+			},
+			"passive": {
+				Description: "Do not form adjacencies on the interface.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"bfd": {
+				Description: "Use BFD to detect neighbor failures.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"authentication": {
+				Description: "Authentication keys. Not supported by OSPFv3.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"plaintext_password": {
+							Description:      "Plaintext password, at most 8 characters.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Sensitive:        true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 8)),
+						},
+						"md5_key": {
+							Description: "MD5 keys.",
+							Type:        schema.TypeSet,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key_id": {
+										Description:      "Key ID.",
+										Type:             schema.TypeInt,
+										Required:         true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 255)),
+									},
+									"key": {
+										Description:      "Key, at most 16 characters.",
+										Type:             schema.TypeString,
+										Required:         true,
+										Sensitive:        true,
+										ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 16)),
+									},
+								},
+							},
+							Optional: true,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "area", Node: "area"},
+			{Attr: "cost", Node: "cost"},
+			{Attr: "priority", Node: "priority"},
+			{Attr: "hello_interval", Node: "hello-interval"},
+			{Attr: "dead_interval", Node: "dead-interval"},
+			{Attr: "retransmit_interval", Node: "retransmit-interval"},
+			{Attr: "transmit_delay", Node: "transmit-delay"},
+			{Attr: "network", Node: "network"},
+			{Attr: "passive", Node: "passive"},
+			{Attr: "bfd", Node: "bfd"},
+			{Attr: "authentication", Node: "authentication", Fields: []configField{
+				{Attr: "plaintext_password", Node: "plaintext-password"},
+				{Attr: "md5_key", Node: "md5 key-id", Key: "key_id", Fields: []configField{
+					{Attr: "key", Node: "md5-key"},
+				}},
+			}},
+		},
+		CustomizeDiff: ospfv3Unsupported("authentication"),
+	}
+	return r.Resource()
+}
@@ -0,0 +1,12 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePKICA() *schema.Resource {
+	return pkiCertificateResource(
+		"This resource manages a certificate authority under `pki ca`, e.g. to verify VPN peers. The private key is only needed to sign certificates on the router. Requires VyOS 1.4 or later.",
+		"pki ca {name}",
+	)
+}
@@ -0,0 +1,12 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePKICertificate() *schema.Resource {
+	return pkiCertificateResource(
+		"This resource manages a certificate and its private key under `pki certificate`, for use by VPNs and the HTTPS service. Requires VyOS 1.4 or later.",
+		"pki certificate {name}",
+	)
+}
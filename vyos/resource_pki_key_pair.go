@@ -0,0 +1,47 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePKIKeyPair() *schema.Resource {
+	attrs := []pemAttribute{
+		{Attr: "public_key", Node: "public key", Object: pemPublicKey},
+		{Attr: "private_key", Node: "private key", Object: pemPrivateKey},
+	}
+
+	r := &configResource{
+		Description: "This resource manages a key pair under `pki key-pair`, e.g. for RSA authentication of IPsec peers. Requires VyOS 1.4 or later.",
+		Path:        "pki key-pair {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name the key pair is referred to by.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"public_key": {
+				Description:      "PEM encoded public key. PKCS #1 RSA keys are converted to PKIX.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: pemPublicKey.validate,
+			},
+			"private_key": {
+				Description:      "PEM encoded private key. PKCS #1 and SEC 1 keys are converted to PKCS #8, encrypted keys are not supported.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				ValidateDiagFunc: pemPrivateKey.validate,
+			},
+			"fingerprint": {
+				Description: "Hex encoded SHA-256 fingerprint of the DER encoded public key.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+		Expand:        expandPEM(attrs),
+		Flatten:       flattenPEM(attrs, "public_key"),
+		CustomizeDiff: pemCustomizeDiff("public_key", pemPublicKey),
+	}
+	return r.Resource()
+}
@@ -0,0 +1,13 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePolicyASPathList() *schema.Resource {
+	return policyRegexListResource(
+		"This resource manages a BGP AS path list, for use in `vyos_policy_route_map` match clauses.",
+		"policy as-path-list {name}",
+		"the AS path, e.g. `^65000_`",
+	)
+}
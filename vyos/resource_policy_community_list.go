@@ -0,0 +1,13 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePolicyCommunityList() *schema.Resource {
+	return policyRegexListResource(
+		"This resource manages a BGP community list, for use in `vyos_policy_route_map` match clauses.",
+		"policy community-list {name}",
+		"the communities, e.g. `65000:10[0-9]`",
+	)
+}
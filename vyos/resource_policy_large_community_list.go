@@ -0,0 +1,13 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePolicyLargeCommunityList() *schema.Resource {
+	return policyRegexListResource(
+		"This resource manages a BGP large community list, for use in `vyos_policy_route_map` match clauses.",
+		"policy large-community-list {name}",
+		"the large communities, e.g. `65000:1:.*`",
+	)
+}
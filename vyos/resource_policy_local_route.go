@@ -0,0 +1,65 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyLocalRoute() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages the local route policy, which selects the routing table of traffic by its source, destination, inbound interface or mark. There is one per address family.",
+		Path:        "policy {type}",
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:      "`local-route` for IPv4 or `local-route6` for IPv6.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "local-route",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"local-route", "local-route6"}, false)),
+			},
+			"rule": {
+				Description: "Rules of the policy, keyed by their number, which orders them.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"number": {
+							Description:      "Rule number, rules are evaluated in ascending order.",
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 32765)),
+						},
+						"source":            optional(schema.TypeString, "Source prefix to match."),
+						"destination":       optional(schema.TypeString, "Destination prefix to match."),
+						"inbound_interface": optional(schema.TypeString, "Inbound interface to match."),
+						"mark":              optional(schema.TypeInt, "Firewall mark to match."),
+						"set_table": {
+							Description: "Routing table to use, a `vyos_route_table` number or `main`.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "rule", Node: "rule", Key: "number", Fields: []configField{
+				{Attr: "source", Node: "source address"},
+				{Attr: "destination", Node: "destination address"},
+				{Attr: "inbound_interface", Node: "inbound-interface"},
+				{Attr: "mark", Node: "fwmark"},
+				{Attr: "set_table", Node: "set table"},
+			}},
+		},
+	}
+	return r.Resource()
+}
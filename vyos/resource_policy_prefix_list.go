@@ -0,0 +1,61 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyPrefixList() *schema.Resource {
+	length := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description:      description,
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 128)),
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages an IPv4 or IPv6 prefix list.",
+		Path:        "policy {type} {name}",
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:      "`prefix-list` for IPv4 or `prefix-list6` for IPv6.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "prefix-list",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"prefix-list", "prefix-list6"}, false)),
+			},
+			"name": {
+				Description: "Name of the list.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"rule": policyRulesSchema("Rules of the list.", map[string]*schema.Schema{
+				"prefix": {
+					Description: "Prefix to match, e.g. `10.0.0.0/8`.",
+					Type:        schema.TypeString,
+					Required:    true,
+				},
+				"ge": length("Match prefixes at least this long."),
+				"le": length("Match prefixes at most this long."),
+			}),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			policyRulesField(
+				configField{Attr: "prefix", Node: "prefix"},
+				configField{Attr: "ge", Node: "ge"},
+				configField{Attr: "le", Node: "le"},
+			),
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,187 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyRoute() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages a policy route, which selects the routing table of forwarded traffic by its source, destination, protocol or mark. Each change is a single commit with the interface bindings.",
+		Path:        "policy {type} {name}",
+		Atomic:      true,
+		AtomicOps:   policyRouteBindingOps,
+		AtomicRead:  policyRouteBindings,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:      "`route` for IPv4 or `route6` for IPv6.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "route",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"route", "route6"}, false)),
+			},
+			"name": {
+				Description: "Name of the policy.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"interfaces": {
+				Description: "Interfaces whose inbound traffic the policy applies to. Before VyOS 1.4 the policy is bound below the interfaces, which must exist then.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+			},
+			"rule": {
+				Description: "Rules of the policy, keyed by their number, which orders them.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"number": {
+							Description:      "Rule number, rules are evaluated in ascending order.",
+							Type:             schema.TypeInt,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 999999)),
+						},
+						"description":         optional(schema.TypeString, "Description."),
+						"disable":             optional(schema.TypeBool, "Disable the rule."),
+						"protocol":            optional(schema.TypeString, "Protocol to match, e.g. `tcp` or `udp`."),
+						"source_address":      optional(schema.TypeString, "Source address, prefix or range to match."),
+						"source_port":         optional(schema.TypeString, "Source ports to match, e.g. `80,443` or `1024-65535`."),
+						"destination_address": optional(schema.TypeString, "Destination address, prefix or range to match."),
+						"destination_port":    optional(schema.TypeString, "Destination ports to match."),
+						"mark":                optional(schema.TypeInt, "Firewall mark to match."),
+						"set_table": {
+							Description: "Routing table to use, a `vyos_route_table` number or `main`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"set_mark": optional(schema.TypeInt, "Firewall mark to set."),
+					},
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "rule", Node: "rule", Key: "number", Fields: []configField{
+				{Attr: "description", Node: "description"},
+				{Attr: "disable", Node: "disable"},
+				{Attr: "protocol", Node: "protocol"},
+				{Attr: "source_address", Node: "source address"},
+				{Attr: "source_port", Node: "source port"},
+				{Attr: "destination_address", Node: "destination address"},
+				{Attr: "destination_port", Node: "destination port"},
+				{Attr: "mark", Node: "mark"},
+				{Attr: "set_table", Node: "set table"},
+				{Attr: "set_mark", Node: "set mark"},
+			}},
+		},
+	}
+	return r.Resource()
+}
+
+// policyRouteBindingOps binds the policy to the interfaces in the
+// `interfaces` attribute. Since VyOS 1.4 they are listed in the policy,
+// before that the policy is set below each interface.
+func policyRouteBindingOps(ctx context.Context, p *ProviderClass, path string, old, new func(string) interface{}) ([]configOp, error) {
+	joining, leaving := map[string]interface{}{}, map[string]interface{}{}
+	for _, item := range configItems(new("interfaces")) {
+		joining[configString(item)] = true
+	}
+	for _, item := range configItems(old("interfaces")) {
+		if _, ok := joining[configString(item)]; ok {
+			delete(joining, configString(item))
+		} else {
+			leaving[configString(item)] = true
+		}
+	}
+	if len(joining) == 0 && len(leaving) == 0 {
+		return nil, nil
+	}
+
+	ops := []configOp{}
+	if !p.version.before(vyos14) {
+		if new("name") == nil {
+			// Deleted with the policy
+			return nil, nil
+		}
+		for _, name := range sortedConfigKeys(joining) {
+			ops = append(ops, configOp{Op: "set", Path: append(strings.Fields(path), "interface", name)})
+		}
+		for _, name := range sortedConfigKeys(leaving) {
+			ops = append(ops, configOp{Op: "delete", Path: append(strings.Fields(path), "interface", name)})
+		}
+		return ops, nil
+	}
+
+	// Read the router instead of the cache, since the config may have
+	// changed earlier in the apply
+	c := *p.client
+	tree, err := c.Config.Show(ctx, "interfaces")
+	if err != nil {
+		return nil, err
+	}
+	interfaces := configMap(tree)
+
+	words := strings.Fields(path)
+	for _, name := range sortedConfigKeys(joining) {
+		interfacePath, _ := interfaceConfig(interfaces, name)
+		if interfacePath == nil {
+			return nil, fmt.Errorf("interface '%s' does not exist, VyOS %s binds policies below the interface", name, p.version)
+		}
+		ops = append(ops, configOp{Op: "set", Path: append(interfacePath, "policy", words[1], words[2])})
+	}
+	for _, name := range sortedConfigKeys(leaving) {
+		interfacePath, config := interfaceConfig(interfaces, name)
+		if node, _ := lookupConfig(config, "policy "+words[1]); interfacePath == nil || configString(node) != words[2] {
+			// Removed or bound to another policy since
+			continue
+		}
+		ops = append(ops, configOp{Op: "delete", Path: append(interfacePath, "policy", words[1])})
+	}
+	return ops, nil
+}
+
+// policyRouteBindings reads the interfaces the policy is bound to.
+func policyRouteBindings(ctx context.Context, p *ProviderClass, path string, tree map[string]interface{}) (map[string]interface{}, error) {
+	if !p.version.before(vyos14) {
+		return map[string]interface{}{"interfaces": configStrings(tree["interface"])}, nil
+	}
+
+	words := strings.Fields(path)
+	interfaces, err := p.ShowCached(ctx, "interfaces")
+	if err != nil {
+		return nil, err
+	}
+	bound := []string{}
+	for _, kind := range sortedConfigKeys(configMap(interfaces)) {
+		for name, config := range configMap(configMap(interfaces)[kind]) {
+			if node, _ := lookupConfig(configMap(config), "policy "+words[1]); configString(node) == words[2] {
+				bound = append(bound, name)
+			}
+			for vlan, vif := range configMap(configMap(config)["vif"]) {
+				if node, _ := lookupConfig(configMap(vif), "policy "+words[1]); configString(node) == words[2] {
+					bound = append(bound, name+"."+vlan)
+				}
+			}
+		}
+	}
+	return map[string]interface{}{"interfaces": bound}, nil
+}
@@ -0,0 +1,161 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePolicyRouteMap() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+	stringSet := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeSet,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional: true,
+		}
+	}
+	origin := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description:      description,
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"egp", "igp", "incomplete"}, false)),
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages a route map. Community `set` clauses require VyOS 1.4 or later.",
+		Path:        "policy route-map {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the route map.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"rule": policyRulesSchema("Rules of the route map.", map[string]*schema.Schema{
+				"call":          optional(schema.TypeString, "Route map to apply before this rule's `set` clauses."),
+				"continue":      optional(schema.TypeInt, "Rule number to continue with after a match."),
+				"on_match_goto": optional(schema.TypeInt, "Rule number to go to after a match."),
+				"on_match_next": optional(schema.TypeBool, "Continue with the next rule after a match."),
+				"match": {
+					Description: "Conditions the rule applies to, all of them must match.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"as_path":                  optional(schema.TypeString, "Name of a `vyos_policy_as_path_list`."),
+							"community":                optional(schema.TypeString, "Name of a `vyos_policy_community_list`."),
+							"community_exact_match":    optional(schema.TypeBool, "Require the communities to match exactly."),
+							"large_community":          optional(schema.TypeString, "Name of a `vyos_policy_large_community_list`."),
+							"interface":                optional(schema.TypeString, "First hop interface."),
+							"ip_address_prefix_list":   optional(schema.TypeString, "Name of an IPv4 `vyos_policy_prefix_list` matching the route."),
+							"ip_nexthop_prefix_list":   optional(schema.TypeString, "Name of an IPv4 `vyos_policy_prefix_list` matching the next hop."),
+							"ipv6_address_prefix_list": optional(schema.TypeString, "Name of an IPv6 `vyos_policy_prefix_list` matching the route."),
+							"metric":                   optional(schema.TypeInt, "Route metric."),
+							"origin":                   origin("BGP origin, `egp`, `igp` or `incomplete`."),
+							"peer":                     optional(schema.TypeString, "Address of the BGP peer."),
+							"tag":                      optional(schema.TypeInt, "Route tag."),
+							"rpki": {
+								Description:      "RPKI validation state, `valid`, `invalid` or `notfound`.",
+								Type:             schema.TypeString,
+								Optional:         true,
+								ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"valid", "invalid", "notfound"}, false)),
+							},
+						},
+					},
+					Optional: true,
+				},
+				"set": {
+					Description: "Changes made to matching routes.",
+					Type:        schema.TypeList,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"as_path_prepend":         optional(schema.TypeString, "AS numbers to prepend, separated by spaces."),
+							"as_path_exclude":         optional(schema.TypeString, "AS numbers to remove, separated by spaces."),
+							"community_add":           stringSet("Communities to add."),
+							"community_replace":       stringSet("Communities replacing the existing ones."),
+							"community_delete":        optional(schema.TypeString, "Name of a `vyos_policy_community_list` of communities to remove."),
+							"community_none":          optional(schema.TypeBool, "Remove all communities."),
+							"large_community_add":     stringSet("Large communities to add."),
+							"large_community_replace": stringSet("Large communities replacing the existing ones."),
+							"large_community_delete":  optional(schema.TypeString, "Name of a `vyos_policy_large_community_list` of large communities to remove."),
+							"large_community_none":    optional(schema.TypeBool, "Remove all large communities."),
+							"local_preference":        optional(schema.TypeInt, "BGP local preference."),
+							"metric":                  optional(schema.TypeString, "Metric, or `+`/`-` followed by an adjustment."),
+							"metric_type":             optional(schema.TypeString, "OSPF external metric type, `type-1` or `type-2`."),
+							"origin":                  origin("BGP origin, `egp`, `igp` or `incomplete`."),
+							"ip_next_hop":             optional(schema.TypeString, "IPv4 next hop."),
+							"ipv6_next_hop_global":    optional(schema.TypeString, "IPv6 global next hop."),
+							"ipv6_next_hop_local":     optional(schema.TypeString, "IPv6 link-local next hop."),
+							"src":                     optional(schema.TypeString, "Preferred source address of the route."),
+							"tag":                     optional(schema.TypeInt, "Route tag."),
+							"table":                   optional(schema.TypeInt, "Routing table to install the route in."),
+							"weight":                  optional(schema.TypeInt, "BGP weight."),
+						},
+					},
+					Optional: true,
+				},
+			}),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			policyRulesField(
+				configField{Attr: "call", Node: "call", Ref: "policy route-map"},
+				configField{Attr: "continue", Node: "continue"},
+				configField{Attr: "on_match_goto", Node: "on-match goto"},
+				configField{Attr: "on_match_next", Node: "on-match next"},
+				configField{Attr: "match", Node: "match", Fields: []configField{
+					{Attr: "as_path", Node: "as-path", Ref: "policy as-path-list"},
+					{Attr: "community", Node: "community community-list", Ref: "policy community-list"},
+					{Attr: "community_exact_match", Node: "community exact-match"},
+					{Attr: "large_community", Node: "large-community large-community-list", Ref: "policy large-community-list"},
+					{Attr: "interface", Node: "interface"},
+					{Attr: "ip_address_prefix_list", Node: "ip address prefix-list", Ref: "policy prefix-list"},
+					{Attr: "ip_nexthop_prefix_list", Node: "ip nexthop prefix-list", Ref: "policy prefix-list"},
+					{Attr: "ipv6_address_prefix_list", Node: "ipv6 address prefix-list", Ref: "policy prefix-list6"},
+					{Attr: "metric", Node: "metric"},
+					{Attr: "origin", Node: "origin"},
+					{Attr: "peer", Node: "peer"},
+					{Attr: "tag", Node: "tag"},
+					{Attr: "rpki", Node: "rpki"},
+				}},
+				configField{Attr: "set", Node: "set", Fields: []configField{
+					{Attr: "as_path_prepend", Node: "as-path prepend"},
+					{Attr: "as_path_exclude", Node: "as-path exclude"},
+					{Attr: "community_add", Node: "community add"},
+					{Attr: "community_replace", Node: "community replace"},
+					{Attr: "community_delete", Node: "community delete", Ref: "policy community-list"},
+					{Attr: "community_none", Node: "community none"},
+					{Attr: "large_community_add", Node: "large-community add"},
+					{Attr: "large_community_replace", Node: "large-community replace"},
+					{Attr: "large_community_delete", Node: "large-community delete", Ref: "policy large-community-list"},
+					{Attr: "large_community_none", Node: "large-community none"},
+					{Attr: "local_preference", Node: "local-preference"},
+					{Attr: "metric", Node: "metric"},
+					{Attr: "metric_type", Node: "metric-type"},
+					{Attr: "origin", Node: "origin"},
+					{Attr: "ip_next_hop", Node: "ip-next-hop"},
+					{Attr: "ipv6_next_hop_global", Node: "ipv6-next-hop global"},
+					{Attr: "ipv6_next_hop_local", Node: "ipv6-next-hop local"},
+					{Attr: "src", Node: "src"},
+					{Attr: "tag", Node: "tag"},
+					{Attr: "table", Node: "table"},
+					{Attr: "weight", Node: "weight"},
+				}},
+			),
+		},
+	}
+	return r.Resource()
+}
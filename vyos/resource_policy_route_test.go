@@ -0,0 +1,90 @@
+package vyos
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPolicyRouteLegacyBindings(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newTestProvider(t, vyos13, map[string]any{
+		"interfaces": map[string]any{
+			"ethernet": map[string]any{
+				"eth1": map[string]any{"policy": map[string]any{"route": "GUESTS"}},
+				"eth2": map[string]any{"vif": map[string]any{"20": map[string]any{}}},
+				"eth3": map[string]any{"policy": map[string]any{"route": "OTHER"}},
+			},
+		},
+	})
+	values := func(interfaces ...interface{}) func(string) interface{} {
+		return func(attr string) interface{} {
+			switch attr {
+			case "name":
+				return "GUESTS"
+			case "interfaces":
+				return schema.NewSet(schema.HashString, interfaces)
+			}
+			return nil
+		}
+	}
+
+	ops, err := policyRouteBindingOps(ctx, p, "policy route GUESTS", values("eth1", "eth3"), values("eth2.20"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []configOp{
+		{Op: "set", Path: []string{"interfaces", "ethernet", "eth2", "vif", "20", "policy", "route", "GUESTS"}},
+		{Op: "delete", Path: []string{"interfaces", "ethernet", "eth1", "policy", "route"}},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Fatalf("got ops %v, want %v", ops, want)
+	}
+
+	if _, err := policyRouteBindingOps(ctx, p, "policy route GUESTS", noValues, values("eth9")); err == nil {
+		t.Fatal("bound a missing interface")
+	}
+
+	read, err := policyRouteBindings(ctx, p, "policy route GUESTS", map[string]interface{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bound := read["interfaces"].([]string)
+	sort.Strings(bound)
+	if !reflect.DeepEqual(bound, []string{"eth1"}) {
+		t.Fatalf("read interfaces %v, want [eth1]", bound)
+	}
+}
+
+func TestPolicyRouteBindings(t *testing.T) {
+	ctx := context.Background()
+	p, _ := newTestProvider(t, vyos14, map[string]any{})
+	values := func(interfaces ...interface{}) func(string) interface{} {
+		return func(attr string) interface{} {
+			if attr == "interfaces" {
+				return schema.NewSet(schema.HashString, interfaces)
+			}
+			return "GUESTS"
+		}
+	}
+
+	ops, err := policyRouteBindingOps(ctx, p, "policy route GUESTS", values("eth1"), values("eth2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []configOp{
+		{Op: "set", Path: []string{"policy", "route", "GUESTS", "interface", "eth2"}},
+		{Op: "delete", Path: []string{"policy", "route", "GUESTS", "interface", "eth1"}},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Fatalf("got ops %v, want %v", ops, want)
+	}
+
+	// The bindings are deleted with the policy
+	if ops, _ := policyRouteBindingOps(ctx, p, "policy route GUESTS", values("eth1"), noValues); len(ops) > 0 {
+		t.Fatalf("got ops %v deleting the policy, want none", ops)
+	}
+}
@@ -0,0 +1,61 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var cakeFlowIsolations = []string{"blind", "src-host", "dst-host", "dual-src-host", "dual-dst-host", "triple-isolate", "flow", "host"}
+
+func resourceQoSCake() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages a CAKE policy, which shapes egress traffic and shares the bandwidth among flows. It is bound to interfaces with `vyos_qos_interface` and requires VyOS 1.4 or later.",
+		Path:        "qos policy cake {name}",
+		Schema: qosPolicySchema(map[string]*schema.Schema{
+			"bandwidth": {
+				Description: "Available bandwidth, e.g. `100mbit`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"rtt": {
+				Description: "Expected round trip time in milliseconds.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"flow_isolation": {
+				Description:      "How flows share the bandwidth: `blind`, `src-host`, `dst-host`, `dual-src-host`, `dual-dst-host`, `triple-isolate`, `flow` or `host`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(cakeFlowIsolations, false)),
+			},
+			"flow_isolation_nat": {
+				Description: "Look up the addresses of flows before NAT.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+		}),
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "bandwidth", Node: "bandwidth"},
+			{Attr: "rtt", Node: "rtt"},
+			{Attr: "flow_isolation_nat", Node: "flow-isolation nat"},
+		},
+		// The isolation mode is a valueless node named after it
+		Expand: func(get func(string) interface{}) []configCommand {
+			if v := configString(get("flow_isolation")); v != "" {
+				return []configCommand{{path: []string{"flow-isolation", v}, owner: 1}}
+			}
+			return nil
+		},
+		Flatten: func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+			node, _ := lookupConfig(tree, "flow-isolation")
+			for _, mode := range cakeFlowIsolations {
+				if _, ok := configMap(node)[mode]; ok {
+					return map[string]interface{}{"flow_isolation": mode}
+				}
+			}
+			return map[string]interface{}{"flow_isolation": nil}
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,30 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceQoSFairQueue() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages a stochastic fair queue policy, which shares the egress bandwidth among flows. It is bound to interfaces with `vyos_qos_interface`. `traffic-policy fair-queue` before VyOS 1.4.",
+		Path:        "qos policy fair-queue {name}",
+		Schema: qosPolicySchema(map[string]*schema.Schema{
+			"hash_interval": {
+				Description: "Seconds between changes of the flow hash, 0 to never change it.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"queue_limit": {
+				Description: "Maximum queue length in packets.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+		}),
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "hash_interval", Node: "hash-interval"},
+			{Attr: "queue_limit", Node: "queue-limit"},
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,36 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceQoSFQCoDel() *schema.Resource {
+	optionalInt := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        schema.TypeInt,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages a fair queuing controlled delay policy, which shares the egress bandwidth among flows and keeps queueing delay low. It is bound to interfaces with `vyos_qos_interface`. `traffic-policy fq-codel` before VyOS 1.4.",
+		Path:        "qos policy fq-codel {name}",
+		Schema: qosPolicySchema(map[string]*schema.Schema{
+			"codel_quantum": optionalInt("Bytes dequeued from a flow at a time."),
+			"flows":         optionalInt("Number of flow queues."),
+			"interval":      optionalInt("Milliseconds the delay is measured over."),
+			"queue_limit":   optionalInt("Maximum queue length in packets."),
+			"target":        optionalInt("Acceptable queueing delay in milliseconds."),
+		}),
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "codel_quantum", Node: "codel-quantum"},
+			{Attr: "flows", Node: "flows"},
+			{Attr: "interval", Node: "interval"},
+			{Attr: "queue_limit", Node: "queue-limit"},
+			{Attr: "target", Node: "target"},
+		},
+	}
+	return r.Resource()
+}
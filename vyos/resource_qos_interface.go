@@ -0,0 +1,37 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceQoSInterface() *schema.Resource {
+	r := &configResource{
+		Description: "This resource binds QoS policies to an interface. Before VyOS 1.4 policies are bound in the interface config as `traffic-policy out` and `in`, which is supported for ethernet, bonding, bridge, PPPoE, OpenVPN, tunnel, WireGuard and VXLAN interfaces but not for VLANs.",
+		Path:        "qos interface {interface}",
+		Schema: map[string]*schema.Schema{
+			"interface": {
+				Description: "Interface name, e.g. `eth0`.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"egress": {
+				Description:  "Name of the policy for outgoing traffic.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"egress", "ingress"},
+			},
+			"ingress": {
+				Description:  "Name of the limiter policy for incoming traffic.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"egress", "ingress"},
+			},
+		},
+		Fields: []configField{
+			{Attr: "egress", Node: "egress"},
+			{Attr: "ingress", Node: "ingress", Ref: "qos policy limiter"},
+		},
+	}
+	return r.Resource()
+}
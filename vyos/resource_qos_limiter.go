@@ -0,0 +1,56 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceQoSLimiter() *schema.Resource {
+	burst := &schema.Schema{
+		Description: "Burst size, e.g. `15k`.",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+
+	r := &configResource{
+		Description: "This resource manages a limiter policy, which drops ingress traffic exceeding the bandwidth of its class. It is bound to interfaces with `vyos_qos_interface`. `traffic-policy limiter` before VyOS 1.4.",
+		Path:        "qos policy limiter {name}",
+		Schema: qosPolicySchema(map[string]*schema.Schema{
+			"class": qosClassesSchema("Traffic classes.", 1, 4090, map[string]*schema.Schema{
+				"bandwidth": qosBandwidthSchema("Bandwidth limit.", true),
+				"burst":     burst,
+				"priority": {
+					Description:      "Priority of the class, matching classes with a higher priority first. 0 is the highest.",
+					Type:             schema.TypeInt,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 20)),
+				},
+			}),
+			"default": {
+				Description: "Limit of traffic not matching any class, unlimited if not set.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bandwidth": qosBandwidthSchema("Bandwidth limit.", true),
+						"burst":     burst,
+					},
+				},
+				Optional: true,
+			},
+		}),
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			qosClassesField(
+				configField{Attr: "bandwidth", Node: "bandwidth"},
+				configField{Attr: "burst", Node: "burst"},
+				configField{Attr: "priority", Node: "priority"},
+			),
+			{Attr: "default", Node: "default", Fields: []configField{
+				{Attr: "bandwidth", Node: "bandwidth"},
+				{Attr: "burst", Node: "burst"},
+			}},
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,79 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceQoSShaper() *schema.Resource {
+	// Settings of both classes and the default class
+	queue := func(class map[string]*schema.Schema) map[string]*schema.Schema {
+		class["ceiling"] = qosBandwidthSchema("Bandwidth the class may borrow up to.", false)
+		class["burst"] = &schema.Schema{
+			Description: "Burst size, e.g. `15k`.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+		class["priority"] = &schema.Schema{
+			Description:      "Priority for borrowing bandwidth, 0 is the highest.",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 7)),
+		}
+		class["queue_type"] = &schema.Schema{
+			Description:      "`fq-codel`, `fair-queue`, `drop-tail`, `priority` or `random-detect`.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"fq-codel", "fair-queue", "drop-tail", "priority", "random-detect"}, false)),
+		}
+		class["queue_limit"] = &schema.Schema{
+			Description: "Maximum queue length in packets.",
+			Type:        schema.TypeInt,
+			Optional:    true,
+		}
+		class["set_dscp"] = &schema.Schema{
+			Description: "DSCP value to rewrite the traffic with.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+		return class
+	}
+	queueFields := []configField{
+		{Attr: "bandwidth", Node: "bandwidth"},
+		{Attr: "ceiling", Node: "ceiling"},
+		{Attr: "burst", Node: "burst"},
+		{Attr: "priority", Node: "priority"},
+		{Attr: "queue_type", Node: "queue-type"},
+		{Attr: "queue_limit", Node: "queue-limit"},
+		{Attr: "set_dscp", Node: "set-dscp"},
+	}
+
+	r := &configResource{
+		Description: "This resource manages a shaper policy, which limits egress traffic and divides the bandwidth among classes. It is bound to interfaces with `vyos_qos_interface`. `traffic-policy shaper` before VyOS 1.4.",
+		Path:        "qos policy shaper {name}",
+		Schema: qosPolicySchema(map[string]*schema.Schema{
+			"bandwidth": qosBandwidthSchema("Available bandwidth, the interface speed if not set.", false),
+			"class": qosClassesSchema("Traffic classes.", 2, 4095, queue(map[string]*schema.Schema{
+				"bandwidth": qosBandwidthSchema("Guaranteed bandwidth.", false),
+			})),
+			"default": {
+				Description: "Settings of traffic not matching any class.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: queue(map[string]*schema.Schema{
+						"bandwidth": qosBandwidthSchema("Guaranteed bandwidth.", true),
+					}),
+				},
+				Required: true,
+			},
+		}),
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "bandwidth", Node: "bandwidth"},
+			qosClassesField(queueFields...),
+			{Attr: "default", Node: "default", Fields: queueFields},
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,124 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceRouteTable() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages an additional routing table and its static routes, e.g. for `vyos_policy_route` rules to select.",
+		Path:        "protocols static table {table}",
+		Schema: map[string]*schema.Schema{
+			"table": {
+				Description:      "Table number.",
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 200)),
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"route":  staticRoutesSchema("IPv4 routes of the table."),
+			"route6": staticRoutesSchema("IPv6 routes of the table."),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			staticRoutesField("route", "route"),
+			staticRoutesField("route6", "route6"),
+		},
+	}
+	return r.Resource()
+}
+
+// staticRoutesSchema is a set of static routes keyed by their destination.
+func staticRoutesSchema(description string) *schema.Schema {
+	route := staticRouteSchema()
+	route["destination"] = &schema.Schema{
+		Description: "Destination prefix, e.g. `0.0.0.0/0`.",
+		Type:        schema.TypeString,
+		Required:    true,
+	}
+
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: route,
+		},
+		Optional: true,
+	}
+}
+
+// staticRouteSchema is the schema of a static route, without its destination.
+func staticRouteSchema() map[string]*schema.Schema {
+	distance := &schema.Schema{
+		Description:      "Administrative distance.",
+		Type:             schema.TypeInt,
+		Optional:         true,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 255)),
+	}
+
+	return map[string]*schema.Schema{
+		"next_hop": {
+			Description: "Gateways of the route.",
+			Type:        schema.TypeSet,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"address": {
+						Description: "Gateway address.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"interface": {
+						Description: "Interface the gateway is reached through.",
+						Type:        schema.TypeString,
+						Optional:    true,
+					},
+					"distance": distance,
+				},
+			},
+			Optional: true,
+		},
+		"interface": {
+			Description: "Interfaces the destination is directly reached through. `interface-route` before VyOS 1.4.",
+			Type:        schema.TypeSet,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Description: "Interface name.",
+						Type:        schema.TypeString,
+						Required:    true,
+					},
+					"distance": distance,
+				},
+			},
+			Optional: true,
+		},
+		"blackhole": {
+			Description: "Discard traffic to the destination.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+		},
+	}
+}
+
+func staticRoutesField(attr, node string) configField {
+	return configField{Attr: attr, Node: node, Key: "destination", Fields: staticRouteFields()}
+}
+
+func staticRouteFields() []configField {
+	return []configField{
+		{Attr: "next_hop", Node: "next-hop", Key: "address", Fields: []configField{
+			{Attr: "interface", Node: "interface"},
+			{Attr: "distance", Node: "distance"},
+		}},
+		{Attr: "interface", Node: "interface", Key: "name", Fields: []configField{
+			{Attr: "distance", Node: "distance"},
+		}},
+		{Attr: "blackhole", Node: "blackhole"},
+	}
+}
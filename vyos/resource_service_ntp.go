@@ -0,0 +1,73 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceServiceNTP() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages the NTP client and server, `system ntp` before VyOS 1.4. It adopts the current config on create, and settings which are not set stay unmanaged.",
+		Path:        "service ntp",
+		Shared:      true,
+		Schema: map[string]*schema.Schema{
+			"server": {
+				Description: "Time servers.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Description: "Host name or address.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"pool": {
+							Description: "The address resolves to a pool of servers.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"prefer": {
+							Description: "Prefer this server over the others.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+						"noselect": {
+							Description: "Monitor the server, but never synchronize to it.",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+					},
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"allow_client": {
+				Description: "Addresses or prefixes allowed to query the server.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"listen_address": {
+				Description: "Addresses the server listens on.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "server", Node: "server", Key: "address", Fields: []configField{
+				{Attr: "pool", Node: "pool"},
+				{Attr: "prefer", Node: "prefer"},
+				{Attr: "noselect", Node: "noselect"},
+			}},
+			{Attr: "allow_client", Node: "allow-client address"},
+			{Attr: "listen_address", Node: "listen-address"},
+		},
+	}
+	return r.Resource()
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -18,6 +19,7 @@ func resourceStaticHostMapping() *schema.Resource {
 		UpdateContext: resourceStaticHostMappingUpdate,
 		DeleteContext: resourceStaticHostMappingDelete,
 		Schema: map[string]*schema.Schema{
+			"device": deviceSchema(),
 			"host": {
 				Description: "Hostname.",
 				Type:        schema.TypeString,
@@ -40,23 +42,28 @@ func resourceStaticHostMapping() *schema.Resource {
 }
 
 func resourceStaticHostMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	host, ip := d.Get("host").(string), d.Get("ip").(string)
 
 	path := fmt.Sprintf("system static-host-mapping host-name %s inet", host)
-	err := c.Config.Set(ctx, path, ip)
+	err = c.Config.Set(ctx, path, ip)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "set", path, cty.GetAttrPath("ip"))
 	}
 
 	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
-	p.conditionalSave(ctx)
-	return diag.Diagnostics{}
+	return p.conditionalSave(ctx)
 }
 
 func resourceStaticHostMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	host := d.Get("host").(string)
 
 	path := fmt.Sprintf("system static-host-mapping host-name %s inet", host)
@@ -73,7 +80,10 @@ func resourceStaticHostMappingRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceStaticHostMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	host, ip := d.Get("host").(string), d.Get("ip").(string)
 
@@ -84,31 +94,32 @@ func resourceStaticHostMappingUpdate(ctx context.Context, d *schema.ResourceData
 		path := fmt.Sprintf("system static-host-mapping host-name %s", old)
 		err := c.Config.Delete(ctx, path)
 		if err != nil {
-			return diag.FromErr(err)
+			return configErrorDiags(err, "delete", path, cty.GetAttrPath("host"))
 		}
 	}
 
 	path := fmt.Sprintf("system static-host-mapping host-name %s inet", host)
-	err := c.Config.Set(ctx, path, ip)
+	err = c.Config.Set(ctx, path, ip)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "set", path, cty.GetAttrPath("ip"))
 	}
 
-	p.conditionalSave(ctx)
-	return diag.Diagnostics{}
+	return p.conditionalSave(ctx)
 }
 
 func resourceStaticHostMappingDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	p := m.(*ProviderClass)
+	p, err := providerDevice(m, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	c := *p.client
 	host := d.Get("host").(string)
 
 	path := fmt.Sprintf("system static-host-mapping host-name %s", host)
-	err := c.Config.Delete(ctx, path)
+	err = c.Config.Delete(ctx, path)
 	if err != nil {
-		return diag.FromErr(err)
+		return configErrorDiags(err, "delete", path, cty.GetAttrPath("host"))
 	}
 
-	p.conditionalSave(ctx)
-	return diag.Diagnostics{}
+	return p.conditionalSave(ctx)
 }
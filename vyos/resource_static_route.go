@@ -0,0 +1,37 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceStaticRoute() *schema.Resource {
+	return staticRouteResource("route", "IPv4", "`10.0.0.0/8`")
+}
+
+func resourceStaticRoute6() *schema.Resource {
+	return staticRouteResource("route6", "IPv6", "`2001:db8::/32`")
+}
+
+// staticRouteResource manages a single static route of the main table, at
+// "protocols static <node>", or of a VRF.
+func staticRouteResource(node, family, example string) *schema.Resource {
+	routeSchema := staticRouteSchema()
+	routeSchema["destination"] = &schema.Schema{
+		Description: "Destination prefix, e.g. " + example + ".",
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+	}
+	for _, attr := range []string{"next_hop", "interface", "blackhole"} {
+		routeSchema[attr].AtLeastOneOf = []string{"next_hop", "interface", "blackhole"}
+	}
+
+	r := &configResource{
+		Description: "This resource manages a static " + family + " route of the main routing table, or of the VRF set by `vrf`. The routes of a VRF are managed either by these resources or by the `" + node + "` blocks of `vyos_vrf`, not both. It can be imported by its destination, or by its ID for a route of a VRF.",
+		Path:        "protocols static " + node + " {destination}",
+		VRF:         true,
+		Schema:      routeSchema,
+		Fields:      staticRouteFields(),
+	}
+	return r.Resource()
+}
@@ -0,0 +1,63 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSystem() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages basic system settings. It adopts the current values on create, and settings which are not set stay unmanaged.",
+		Path:        "system",
+		Shared:      true,
+		Schema: map[string]*schema.Schema{
+			"host_name": {
+				Description: "Host name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"domain_name": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"name_servers": {
+				Description: "DNS servers used by the router itself.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional: true,
+				Computed: true,
+			},
+			"time_zone": {
+				Description: "Time zone, e.g. `Europe/Berlin`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"login_banner_pre_login": {
+				Description: "Banner shown before login.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"login_banner_post_login": {
+				Description: "Banner shown after login.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "host_name", Node: "host-name"},
+			{Attr: "domain_name", Node: "domain-name"},
+			{Attr: "name_servers", Node: "name-server"},
+			{Attr: "time_zone", Node: "time-zone"},
+			{Attr: "login_banner_pre_login", Node: "login banner pre-login"},
+			{Attr: "login_banner_post_login", Node: "login banner post-login"},
+		},
+	}
+	return r.Resource()
+}
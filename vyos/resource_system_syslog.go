@@ -0,0 +1,96 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var (
+	syslogFacilities = []string{
+		"all", "auth", "authpriv", "cron", "daemon", "kern", "lpr", "mail", "mark", "news",
+		"protocols", "security", "syslog", "user", "uucp",
+		"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+	}
+	syslogLevels = []string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug", "all"}
+)
+
+func resourceSystemSyslog() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages logging to local files, the console and remote syslog hosts. It adopts the current config on create, and settings which are not set stay unmanaged.",
+		Path:        "system syslog",
+		Shared:      true,
+		Schema: map[string]*schema.Schema{
+			"local_facility":   syslogFacilitySchema("Facilities logged locally. `global` before VyOS 1.5."),
+			"console_facility": syslogFacilitySchema("Facilities logged to the console."),
+			"remote": {
+				Description: "Remote syslog hosts. `host` before VyOS 1.5.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Description: "Host name or address.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"port": {
+							Description:      "Port, 514 by default.",
+							Type:             schema.TypeInt,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IsPortNumber),
+						},
+						"protocol": {
+							Description:      "`udp` or `tcp`. Requires VyOS 1.4 or later.",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"udp", "tcp"}, false)),
+						},
+						"facility": syslogFacilitySchema("Facilities sent to the host."),
+					},
+				},
+				Optional: true,
+				Computed: true,
+			},
+		},
+		Fields: []configField{
+			syslogFacilityField("local_facility", "local facility"),
+			syslogFacilityField("console_facility", "console facility"),
+			{Attr: "remote", Node: "remote", Key: "address", Fields: []configField{
+				{Attr: "port", Node: "port"},
+				{Attr: "protocol", Node: "protocol"},
+				syslogFacilityField("facility", "facility"),
+			}},
+		},
+	}
+	return r.Resource()
+}
+
+func syslogFacilitySchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeSet,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description:      "Facility, e.g. `all` or `local7`.",
+					Type:             schema.TypeString,
+					Required:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(syslogFacilities, false)),
+				},
+				"level": {
+					Description:      "Lowest level logged, e.g. `info`.",
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(syslogLevels, false)),
+				},
+			},
+		},
+		Optional: true,
+		Computed: true,
+	}
+}
+
+func syslogFacilityField(attr, node string) configField {
+	return configField{Attr: attr, Node: node, Key: "name", Fields: []configField{
+		{Attr: "level", Node: "level"},
+	}}
+}
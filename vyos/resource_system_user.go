@@ -0,0 +1,247 @@
+package vyos
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSystemUser() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages a login user and their SSH keys. It refuses to delete the provider `session_user`, there is no such guard when that is not set.",
+		Path:        "system login user {username}",
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Description: "Login name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"full_name": {
+				Description: "Full name of the user.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"home_directory": {
+				Description: "Home directory, `/home/<username>` by default.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"level": {
+				Description:      "User level, `admin` or `operator`. Only supported before VyOS 1.4.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"admin", "operator"}, false)),
+			},
+			"encrypted_password": {
+				Description: "Encrypted password. VyOS sets it from `plaintext_password_wo` otherwise.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"plaintext_password_wo": {
+				Description:   "Plaintext password, which is not stored in the state. It is only sent when the user is created or `plaintext_password_wo_version` changes. Requires Terraform 1.11 or later.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				WriteOnly:     true,
+				Sensitive:     true,
+				ConflictsWith: []string{"encrypted_password"},
+			},
+			"plaintext_password_wo_version": {
+				Description:  "Change to set `plaintext_password_wo` again.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"plaintext_password_wo"},
+			},
+			"ssh_keys": {
+				Description: "SSH public keys in `authorized_keys` format, `[options] type key [comment]`. The comment names the key on the router.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateAuthorizedKey,
+				},
+				Optional: true,
+			},
+		},
+		Fields: []configField{
+			{Attr: "full_name", Node: "full-name"},
+			{Attr: "home_directory", Node: "home-directory"},
+			{Attr: "level", Node: "level"},
+			{Attr: "encrypted_password", Node: "authentication encrypted-password"},
+			{Attr: "plaintext_password_wo", Node: "authentication plaintext-password"},
+		},
+		Expand:  expandSSHKeys,
+		Flatten: flattenSSHKeys,
+		BeforeDelete: func(p *ProviderClass, d *schema.ResourceData) error {
+			user := p.schema.Get("session_user").(string)
+			if user != "" && user == d.Get("username").(string) {
+				return fmt.Errorf("Refusing to delete user '%s', the provider session depends on it. Change `session_user` first if this is intended.", user)
+			}
+			return nil
+		},
+	}
+	return r.Resource()
+}
+
+var (
+	sshKeyType     = regexp.MustCompile(`^(ssh-(rsa|dss|ed25519)|ecdsa-sha2-nistp(256|384|521)|sk-(ssh-ed25519|ecdsa-sha2-nistp256)@openssh\.com)$`)
+	sshKeyNameChar = regexp.MustCompile(`[^A-Za-z0-9@._-]+`)
+)
+
+// authorizedKey is a line of an authorized_keys file.
+type authorizedKey struct {
+	options string
+	keyType string
+	key     string
+	comment string
+}
+
+func parseAuthorizedKey(line string) (authorizedKey, error) {
+	var k authorizedKey
+	line = strings.TrimSpace(line)
+
+	if fields := strings.Fields(line); len(fields) > 0 && !sshKeyType.MatchString(fields[0]) {
+		// Options come first, and may contain quoted whitespace
+		quoted, i := false, 0
+		for ; i < len(line); i++ {
+			if line[i] == '"' {
+				quoted = !quoted
+			} else if !quoted && (line[i] == ' ' || line[i] == '\t') {
+				break
+			}
+		}
+		k.options, line = line[:i], line[i:]
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) < 2 || !sshKeyType.MatchString(fields[0]) {
+		return k, fmt.Errorf("expected an authorized_keys line of the form '[options] type key [comment]'")
+	}
+	if _, err := base64.StdEncoding.DecodeString(fields[1]); err != nil {
+		return k, fmt.Errorf("invalid %s key: %s", fields[0], err)
+	}
+	k.keyType, k.key, k.comment = fields[0], fields[1], strings.Join(fields[2:], " ")
+	return k, nil
+}
+
+func (k authorizedKey) String() string {
+	words := []string{k.keyType, k.key}
+	if k.options != "" {
+		words = append([]string{k.options}, words...)
+	}
+	if k.comment != "" {
+		words = append(words, k.comment)
+	}
+	return strings.Join(words, " ")
+}
+
+// name is the public-keys node of the key, its comment or else derived
+// from the key itself.
+func (k authorizedKey) name() string {
+	if name := strings.Trim(sshKeyNameChar.ReplaceAllString(k.comment, "-"), "-"); name != "" {
+		return name
+	}
+	sum := sha256.Sum256([]byte(k.key))
+	return "key-" + hex.EncodeToString(sum[:4])
+}
+
+// identity is what a key is compared by, ignoring the comment.
+func (k authorizedKey) identity() string {
+	return k.options + "\x00" + k.keyType + "\x00" + k.key
+}
+
+func validateAuthorizedKey(value interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := parseAuthorizedKey(value.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid SSH key",
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+// expandSSHKeys sets the keys under their names. Keys sharing a name are
+// told apart by a hash of the key, not by their order in the set, so a key
+// keeps its name when others are added or removed.
+func expandSSHKeys(get func(string) interface{}) []configCommand {
+	keys := []authorizedKey{}
+	shared := map[string]int{}
+	for _, item := range configItems(get("ssh_keys")) {
+		if k, err := parseAuthorizedKey(configString(item)); err == nil {
+			keys = append(keys, k)
+			shared[k.name()]++
+		}
+	}
+
+	commands := []configCommand{}
+	used := map[string]bool{}
+	for _, k := range keys {
+		name := k.name()
+		if shared[name] > 1 {
+			sum := sha256.Sum256([]byte(k.identity()))
+			name += "-" + hex.EncodeToString(sum[:4])
+		}
+		if used[name] {
+			// The same key twice
+			continue
+		}
+		used[name] = true
+
+		base := []string{"authentication", "public-keys", name}
+		commands = append(commands,
+			configCommand{path: append(base[:3:3], "key"), value: k.key, owner: 2},
+			configCommand{path: append(base[:3:3], "type"), value: k.keyType, owner: 2},
+		)
+		if k.options != "" {
+			commands = append(commands, configCommand{path: append(base[:3:3], "options"), value: k.options, owner: 2})
+		}
+	}
+	return commands
+}
+
+// flattenSSHKeys reads the keys back, keeping the configured line of keys
+// that did not change so comments which are not valid names round trip.
+func flattenSSHKeys(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+	configured := map[string]string{}
+	for _, item := range configItems(get("ssh_keys")) {
+		if k, err := parseAuthorizedKey(configString(item)); err == nil {
+			configured[k.identity()] = configString(item)
+		}
+	}
+
+	node, _ := lookupConfig(tree, "authentication public-keys")
+	keys := configMap(node)
+
+	lines := []interface{}{}
+	for _, name := range sortedConfigKeys(keys) {
+		key := configMap(keys[name])
+		k := authorizedKey{comment: name}
+		if v := configStrings(key["options"]); len(v) > 0 {
+			k.options = v[0]
+		}
+		if v := configStrings(key["type"]); len(v) > 0 {
+			k.keyType = v[0]
+		}
+		if v := configStrings(key["key"]); len(v) > 0 {
+			k.key = v[0]
+		}
+
+		if line, ok := configured[k.identity()]; ok {
+			lines = append(lines, line)
+		} else {
+			lines = append(lines, k.String())
+		}
+	}
+	return map[string]interface{}{"ssh_keys": lines}
+}
@@ -0,0 +1,49 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVRF() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages a VRF and its static routes. Static routes can also be managed by `vyos_static_route` and `vyos_static_route6` resources instead of the `route` and `route6` blocks. Protocols in the VRF and its interfaces are managed by resources with a `vrf` attribute, e.g. `vyos_ospf`.",
+		Path:        "vrf name {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the VRF.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"table": {
+				Description:      "Routing table of the VRF. It can not be changed once the VRF exists.",
+				Type:             schema.TypeInt,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(100, 65535)),
+			},
+			"description": {
+				Description: "Description.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"vni": {
+				Description:      "EVPN L3 VNI of the VRF.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 16777214)),
+			},
+			"route":  staticRoutesSchema("IPv4 static routes of the VRF."),
+			"route6": staticRoutesSchema("IPv6 static routes of the VRF."),
+		},
+		Fields: []configField{
+			{Attr: "table", Node: "table"},
+			{Attr: "description", Node: "description"},
+			{Attr: "vni", Node: "vni"},
+			staticRoutesField("route", "protocols static route"),
+			staticRoutesField("route6", "protocols static route6"),
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,173 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVRRPGroup() *schema.Resource {
+	optional := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{
+			Description: description,
+			Type:        t,
+			Optional:    true,
+		}
+	}
+
+	r := &configResource{
+		Description: "This resource manages a VRRP group, whose virtual addresses move to the router with the highest priority.",
+		Path:        "high-availability vrrp group {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the group.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"description": optional(schema.TypeString, "Description."),
+			"interface": {
+				Description: "Interface VRRP runs on.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"vrid": {
+				Description:      "Virtual router ID, the same on all routers of the group.",
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 255)),
+			},
+			"addresses": {
+				Description: "Virtual addresses with prefix length, e.g. `192.0.2.1/24`. `virtual-address` before VyOS 1.4.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required: true,
+			},
+			"priority": {
+				Description:      "Priority of this router, 100 by default.",
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 255)),
+			},
+			"preempt": {
+				Description: "Take over from a router with a lower priority.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"preempt_delay":        optional(schema.TypeInt, "Seconds to wait before preempting."),
+			"advertise_interval":   optional(schema.TypeInt, "Seconds between advertisements."),
+			"hello_source_address": optional(schema.TypeString, "Source address of advertisements."),
+			"peer_address":         optional(schema.TypeString, "Address to send advertisements to with unicast instead of multicast."),
+			"authentication": {
+				Description: "Authentication of advertisements.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description:      "`plaintext-password` or `ah`.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"plaintext-password", "ah"}, false)),
+						},
+						"password": {
+							Description:      "Password, at most 8 characters.",
+							Type:             schema.TypeString,
+							Required:         true,
+							Sensitive:        true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 8)),
+						},
+					},
+				},
+				Optional: true,
+			},
+			"health_check": {
+				Description: "Script deciding whether the router is fit to be master.",
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"script": {
+							Description: "Path of the script, which fails when the router is not healthy.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+						"interval":      optional(schema.TypeInt, "Seconds between checks."),
+						"failure_count": optional(schema.TypeInt, "Failed checks until the router enters the fault state."),
+					},
+				},
+				Optional: true,
+			},
+			"transition_script": vrrpTransitionScriptSchema(),
+		},
+		Fields: []configField{
+			{Attr: "description", Node: "description"},
+			{Attr: "interface", Node: "interface"},
+			{Attr: "vrid", Node: "vrid"},
+			{Attr: "addresses", Node: "address"},
+			{Attr: "priority", Node: "priority"},
+			{Attr: "preempt_delay", Node: "preempt-delay"},
+			{Attr: "advertise_interval", Node: "advertise-interval"},
+			{Attr: "hello_source_address", Node: "hello-source-address"},
+			{Attr: "peer_address", Node: "peer-address"},
+			{Attr: "authentication", Node: "authentication", Fields: []configField{
+				{Attr: "type", Node: "type"},
+				{Attr: "password", Node: "password"},
+			}},
+			{Attr: "health_check", Node: "health-check", Fields: []configField{
+				{Attr: "script", Node: "script"},
+				{Attr: "interval", Node: "interval"},
+				{Attr: "failure_count", Node: "failure-count"},
+			}},
+			vrrpTransitionScriptField(),
+		},
+		// VyOS preempts unless no-preempt is set
+		Expand: func(get func(string) interface{}) []configCommand {
+			if v, _ := get("preempt").(bool); !v {
+				return []configCommand{{path: []string{"no-preempt"}, owner: 1}}
+			}
+			return nil
+		},
+		Flatten: func(tree map[string]interface{}, get func(string) interface{}) map[string]interface{} {
+			_, noPreempt := lookupConfig(tree, "no-preempt")
+			return map[string]interface{}{"preempt": !noPreempt}
+		},
+	}
+	return r.Resource()
+}
+
+func vrrpTransitionScriptSchema() *schema.Schema {
+	script := func(state string) *schema.Schema {
+		return &schema.Schema{
+			Description: "Path of the script run when entering the " + state + " state.",
+			Type:        schema.TypeString,
+			Optional:    true,
+		}
+	}
+
+	return &schema.Schema{
+		Description: "Scripts run on state transitions.",
+		Type:        schema.TypeList,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"master": script("master"),
+				"backup": script("backup"),
+				"fault":  script("fault"),
+				"stop":   script("stop"),
+			},
+		},
+		Optional: true,
+	}
+}
+
+func vrrpTransitionScriptField() configField {
+	return configField{Attr: "transition_script", Node: "transition-script", Fields: []configField{
+		{Attr: "master", Node: "master"},
+		{Attr: "backup", Node: "backup"},
+		{Attr: "fault", Node: "fault"},
+		{Attr: "stop", Node: "stop"},
+	}}
+}
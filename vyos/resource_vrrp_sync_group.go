@@ -0,0 +1,34 @@
+package vyos
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceVRRPSyncGroup() *schema.Resource {
+	r := &configResource{
+		Description: "This resource manages a VRRP sync group, whose member groups change state together. The members must exist when it is applied.",
+		Path:        "high-availability vrrp sync-group {name}",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name of the sync group.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"members": {
+				Description: "Names of the `vyos_vrrp_group`s in the sync group.",
+				Type:        schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Required: true,
+			},
+			"transition_script": vrrpTransitionScriptSchema(),
+		},
+		Fields: []configField{
+			{Attr: "members", Node: "member", Ref: "high-availability vrrp group"},
+			vrrpTransitionScriptField(),
+		},
+	}
+	return r.Resource()
+}
@@ -0,0 +1,260 @@
+package vyos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/foltik/vyos-client-go/client"
+)
+
+const (
+	retryInitialBackoff = 500 * time.Millisecond
+	retryMaxBackoff     = 30 * time.Second
+)
+
+// errorClass tells whether a failed request is worth retrying.
+type errorClass int
+
+const (
+	errorPermanent errorClass = iota
+	// errorLocked is returned while another session commits to the router.
+	errorLocked
+	// errorRefused means the router did not handle the request, the
+	// connection was refused or the API is unavailable.
+	errorRefused
+	// errorTransient covers timeouts, dropped connections and other 5xx
+	// responses. The router may have handled the request.
+	errorTransient
+)
+
+var (
+	lockedErrorPattern    = regexp.MustCompile(`(?i)locked|commit (is )?(already )?in progress|another commit`)
+	refusedErrorPattern   = regexp.MustCompile(`(?i)\b503 service unavailable\b|status code:? 503\b|connection refused`)
+	transientErrorPattern = regexp.MustCompile(`(?i)\b(500 internal server error|502 bad gateway|504 gateway timeout)\b|status code:? 5\d\d\b|connection reset|timed? ?out|\bEOF\b`)
+)
+
+func classifyError(err error) errorClass {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// The resource timeout has passed, stop retrying
+		return errorPermanent
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return errorTransient
+	}
+
+	msg := err.Error()
+	switch {
+	case lockedErrorPattern.MatchString(msg):
+		return errorLocked
+	case refusedErrorPattern.MatchString(msg):
+		return errorRefused
+	case transientErrorPattern.MatchString(msg):
+		return errorTransient
+	default:
+		return errorPermanent
+	}
+}
+
+// requestLimiter bounds the requests in flight to a router, and retries the
+// ones failing with locked, refused or transient errors with exponential
+// backoff. Retries stop at the deadline of ctx, which is the Timeouts of the
+// resource. Requests changing the config also wait their turn in a
+// writeQueue, as VyOS handles concurrent commits poorly, and are not retried
+// after transient errors, as the change may have been committed.
+type requestLimiter struct {
+	slots  chan struct{}
+	writes *writeQueue
+}
+
+func newRequestLimiter(maxRequests int, maxWrites int) *requestLimiter {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+	return &requestLimiter{
+		slots:  make(chan struct{}, maxRequests),
+		writes: newWriteQueue(maxWrites),
+	}
+}
+
+// write is do for requests changing the config.
+func (l *requestLimiter) write(ctx context.Context, request func() error) error {
+	if err := l.writes.acquire(ctx); err != nil {
+		return err
+	}
+	defer l.writes.release()
+	return l.retry(ctx, request, func(class errorClass) bool {
+		return class == errorLocked || class == errorRefused
+	})
+}
+
+func (l *requestLimiter) do(ctx context.Context, request func() error) error {
+	return l.retry(ctx, request, func(class errorClass) bool {
+		return class != errorPermanent
+	})
+}
+
+// retry runs request until it succeeds, or fails with an error of a class
+// retryable rejects.
+func (l *requestLimiter) retry(ctx context.Context, request func() error, retryable func(errorClass) bool) error {
+	backoff := retryInitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := l.once(ctx, request)
+		if err == nil {
+			return nil
+		}
+		if class := classifyError(err); !retryable(class) {
+			if class == errorTransient {
+				return fmt.Errorf("%w (not retried, the change may have been applied, refresh to see the router state)", err)
+			}
+			return err
+		}
+
+		// Jitter, so parallel resources waiting on the same lock spread out
+		wait := time.Duration(rand.Int63n(int64(backoff))) + backoff/2
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return fmt.Errorf("%w (gave up after %d attempts, the resource timeout would pass before the next one)", err, attempt)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (gave up after %d attempts: %s)", err, attempt, ctx.Err())
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, retryMaxBackoff)
+	}
+}
+
+func (l *requestLimiter) once(ctx context.Context, request func() error) error {
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-l.slots }()
+	return request()
+}
+
+// writeQueue lets at most `parallel` writers run at once, and the others in
+// the order they arrived.
+type writeQueue struct {
+	mu       sync.Mutex
+	parallel int
+	running  int
+	waiting  []chan struct{}
+}
+
+func newWriteQueue(parallel int) *writeQueue {
+	if parallel < 1 {
+		parallel = 1
+	}
+	return &writeQueue{parallel: parallel}
+}
+
+func (q *writeQueue) acquire(ctx context.Context) error {
+	q.mu.Lock()
+	if q.running < q.parallel && len(q.waiting) == 0 {
+		q.running++
+		q.mu.Unlock()
+		return nil
+	}
+	turn := make(chan struct{})
+	q.waiting = append(q.waiting, turn)
+	q.mu.Unlock()
+
+	select {
+	case <-turn:
+		return nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		for i, w := range q.waiting {
+			if w == turn {
+				q.waiting = append(q.waiting[:i], q.waiting[i+1:]...)
+				q.mu.Unlock()
+				return ctx.Err()
+			}
+		}
+		q.mu.Unlock()
+		// Our turn came at the same time, pass it on
+		q.release()
+		return ctx.Err()
+	}
+}
+
+// release hands the slot of a finished writer to the next one waiting.
+func (q *writeQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.waiting) > 0 {
+		close(q.waiting[0])
+		q.waiting = q.waiting[1:]
+	} else {
+		q.running--
+	}
+}
+
+// routerClient wraps client.Client with a requestLimiter. It has the same
+// shape, so resources use it the same way.
+type routerClient struct {
+	Config *routerConfig
+}
+
+type routerConfig struct {
+	config  configService
+	limiter *requestLimiter
+}
+
+// configService is the part of client.ConfigService the provider uses.
+type configService interface {
+	Show(ctx context.Context, path string) (any, error)
+	Set(ctx context.Context, path string, value any) error
+	Delete(ctx context.Context, path string, values ...any) error
+	Save(ctx context.Context) error
+	SaveFile(ctx context.Context, file string) error
+}
+
+func newRouterClient(c *client.Client, limiter *requestLimiter) *routerClient {
+	return &routerClient{Config: &routerConfig{config: c.Config, limiter: limiter}}
+}
+
+func (r *routerConfig) Show(ctx context.Context, path string) (any, error) {
+	var value any
+	err := r.limiter.do(ctx, func() (err error) {
+		value, err = r.config.Show(ctx, path)
+		return err
+	})
+	return value, err
+}
+
+func (r *routerConfig) Set(ctx context.Context, path string, value any) error {
+	return r.limiter.write(ctx, func() error {
+		return r.config.Set(ctx, path, value)
+	})
+}
+
+func (r *routerConfig) Delete(ctx context.Context, path string, values ...any) error {
+	return r.limiter.write(ctx, func() error {
+		return r.config.Delete(ctx, path, values...)
+	})
+}
+
+func (r *routerConfig) Save(ctx context.Context) error {
+	return r.limiter.write(ctx, func() error {
+		return r.config.Save(ctx)
+	})
+}
+
+func (r *routerConfig) SaveFile(ctx context.Context, file string) error {
+	return r.limiter.write(ctx, func() error {
+		return r.config.SaveFile(ctx, file)
+	})
+}
@@ -0,0 +1,276 @@
+package vyos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeConfig records the writes made to it. Writes block until gate is
+// closed, so tests control when they finish.
+type fakeConfig struct {
+	gate chan struct{}
+
+	mu          sync.Mutex
+	writes      []string
+	inflight    int
+	maxInflight int
+}
+
+func newFakeConfig() *fakeConfig {
+	return &fakeConfig{gate: make(chan struct{})}
+}
+
+func (f *fakeConfig) write(ctx context.Context, path string) error {
+	f.mu.Lock()
+	f.writes = append(f.writes, path)
+	f.inflight++
+	f.maxInflight = max(f.maxInflight, f.inflight)
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inflight--
+		f.mu.Unlock()
+	}()
+	select {
+	case <-f.gate:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *fakeConfig) Show(ctx context.Context, path string) (any, error) {
+	return map[string]any{}, nil
+}
+
+func (f *fakeConfig) Set(ctx context.Context, path string, value any) error {
+	return f.write(ctx, path)
+}
+
+func (f *fakeConfig) Delete(ctx context.Context, path string, values ...any) error {
+	return f.write(ctx, path)
+}
+
+func (f *fakeConfig) Save(ctx context.Context) error {
+	return f.write(ctx, "save")
+}
+
+func (f *fakeConfig) SaveFile(ctx context.Context, file string) error {
+	return f.write(ctx, file)
+}
+
+func (f *fakeConfig) state() (writes []string, inflight, maxInflight int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.writes...), f.inflight, f.maxInflight
+}
+
+func newTestRouterClient(fake *fakeConfig, maxWrites int) *routerClient {
+	return &routerClient{Config: &routerConfig{config: fake, limiter: newRequestLimiter(16, maxWrites)}}
+}
+
+func (q *writeQueue) waitingCount() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.waiting)
+}
+
+// eventually fails the test if cond does not become true within a second.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// setAsync starts a Set, returning the channel its error is sent on.
+func setAsync(ctx context.Context, c *routerClient, path string) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Config.Set(ctx, path, "")
+	}()
+	return done
+}
+
+func TestWriteQueueOrder(t *testing.T) {
+	fake := newFakeConfig()
+	c := newTestRouterClient(fake, 1)
+	queue := c.Config.limiter.writes
+	ctx := context.Background()
+
+	// The first write holds the queue, the others arrive one at a time
+	results := []<-chan error{setAsync(ctx, c, "write 0")}
+	eventually(t, "the first write to start", func() bool {
+		_, inflight, _ := fake.state()
+		return inflight == 1
+	})
+	for i := 1; i < 10; i++ {
+		results = append(results, setAsync(ctx, c, fmt.Sprintf("write %d", i)))
+		eventually(t, fmt.Sprintf("write %d to queue", i), func() bool {
+			return queue.waitingCount() == i
+		})
+	}
+
+	close(fake.gate)
+	for i, done := range results {
+		if err := <-done; err != nil {
+			t.Fatalf("write %d: %s", i, err)
+		}
+	}
+
+	writes, _, _ := fake.state()
+	for i, path := range writes {
+		if want := fmt.Sprintf("write %d", i); path != want {
+			t.Fatalf("writes ran in order %v, want write 0 to write 9", writes)
+		}
+	}
+}
+
+func TestWriteQueueParallelLimit(t *testing.T) {
+	const parallel, writers = 3, 20
+
+	fake := newFakeConfig()
+	c := newTestRouterClient(fake, parallel)
+	queue := c.Config.limiter.writes
+	ctx := context.Background()
+
+	results := []<-chan error{}
+	for i := 0; i < writers; i++ {
+		results = append(results, setAsync(ctx, c, fmt.Sprintf("write %d", i)))
+	}
+	eventually(t, "all writes to start or queue", func() bool {
+		_, inflight, _ := fake.state()
+		return inflight == parallel && queue.waitingCount() == writers-parallel
+	})
+
+	close(fake.gate)
+	for i, done := range results {
+		if err := <-done; err != nil {
+			t.Fatalf("write %d: %s", i, err)
+		}
+	}
+
+	writes, _, maxInflight := fake.state()
+	if len(writes) != writers {
+		t.Fatalf("%d writes ran, want %d", len(writes), writers)
+	}
+	if maxInflight != parallel {
+		t.Fatalf("%d writes ran at once, want at most %d", maxInflight, parallel)
+	}
+}
+
+func TestWriteQueueCancelWhileWaiting(t *testing.T) {
+	fake := newFakeConfig()
+	c := newTestRouterClient(fake, 1)
+	queue := c.Config.limiter.writes
+
+	first := setAsync(context.Background(), c, "first")
+	eventually(t, "the first write to start", func() bool {
+		_, inflight, _ := fake.state()
+		return inflight == 1
+	})
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancelled := setAsync(cancelCtx, c, "cancelled")
+	eventually(t, "the cancelled write to queue", func() bool { return queue.waitingCount() == 1 })
+	last := setAsync(context.Background(), c, "last")
+	eventually(t, "the last write to queue", func() bool { return queue.waitingCount() == 2 })
+
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled write returned %v, want context.Canceled", err)
+	}
+	if n := queue.waitingCount(); n != 1 {
+		t.Fatalf("%d writes waiting after the cancel, want 1", n)
+	}
+
+	close(fake.gate)
+	for _, done := range []<-chan error{first, last} {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writes, _, _ := fake.state()
+	if len(writes) != 2 || writes[0] != "first" || writes[1] != "last" {
+		t.Fatalf("writes %v, want [first last]", writes)
+	}
+
+	// The slot is free again once all writers are done
+	if err := <-setAsync(context.Background(), c, "after"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriteQueueReadsBypass(t *testing.T) {
+	fake := newFakeConfig()
+	c := newTestRouterClient(fake, 1)
+	queue := c.Config.limiter.writes
+	ctx := context.Background()
+
+	first := setAsync(ctx, c, "first")
+	eventually(t, "the first write to start", func() bool {
+		_, inflight, _ := fake.state()
+		return inflight == 1
+	})
+	second := setAsync(ctx, c, "second")
+	eventually(t, "the second write to queue", func() bool { return queue.waitingCount() == 1 })
+
+	readCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if _, err := c.Config.Show(readCtx, "system"); err != nil {
+		t.Fatalf("read while writes are queued: %s", err)
+	}
+
+	close(fake.gate)
+	for _, done := range []<-chan error{first, second} {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWriteRetries(t *testing.T) {
+	tests := []struct {
+		err   string
+		read  bool
+		write bool
+	}{
+		{"configuration is locked", true, true},
+		{"503 Service Unavailable", true, true},
+		{"dial tcp 192.0.2.1:443: connect: connection refused", true, true},
+		{"read tcp 192.0.2.1:443: connection reset by peer", true, false},
+		{"Post \"https://192.0.2.1/configure\": EOF", true, false},
+		{"504 Gateway Timeout", true, false},
+		{"Configuration path: [system foo] is not valid", false, false},
+	}
+	l := newRequestLimiter(1, 1)
+	for _, tt := range tests {
+		for _, write := range []bool{false, true} {
+			calls := 0
+			request := func() error {
+				if calls++; calls == 1 {
+					return errors.New(tt.err)
+				}
+				return nil
+			}
+			do, want := l.do, tt.read
+			if write {
+				do, want = l.write, tt.write
+			}
+			err := do(context.Background(), request)
+			if retried := calls > 1; retried != want || (err == nil) != want {
+				t.Errorf("%q, write %t: retried %t with error %v, want retried %t", tt.err, write, retried, err, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package vyos
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// deferredSaves tracks the routers changed in the deferred save mode, which
+// are saved every save_interval seconds. Failures of those saves are
+// reported by the next change or vyos_config_save of the router.
+//
+// SaveDeferred also saves them when the provider shuts down, but Terraform
+// kills the provider shortly after stopping it, so that save may not finish.
+var deferredSaves = &saveRegistry{
+	dirty:  map[*ProviderClass]bool{},
+	failed: map[*ProviderClass]diag.Diagnostics{},
+	stop:   make(chan struct{}),
+}
+
+type saveRegistry struct {
+	mu     sync.Mutex
+	dirty  map[*ProviderClass]bool
+	failed map[*ProviderClass]diag.Diagnostics
+
+	// stop is closed by SaveDeferred to stop the periodic saves.
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func (r *saveRegistry) markDirty(p *ProviderClass) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirty[p] = true
+}
+
+// take clears the dirty mark of p, returning whether it was set.
+func (r *saveRegistry) take(p *ProviderClass) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	dirty := r.dirty[p]
+	delete(r.dirty, p)
+	return dirty
+}
+
+func (r *saveRegistry) pending() []*ProviderClass {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	providers := []*ProviderClass{}
+	for p := range r.dirty {
+		providers = append(providers, p)
+	}
+	return providers
+}
+
+// fail records the diagnostics of a failed periodic save of p, replacing
+// those of earlier attempts.
+func (r *saveRegistry) fail(p *ProviderClass, diags diag.Diagnostics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(diags) == 0 {
+		delete(r.failed, p)
+	} else {
+		r.failed[p] = diags
+	}
+}
+
+// failures returns and clears the diagnostics of the last failed periodic
+// save of p.
+func (r *saveRegistry) failures(p *ProviderClass) diag.Diagnostics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	diags := r.failed[p]
+	delete(r.failed, p)
+	return diags
+}
+
+// saveDirty saves p if it has unsaved changes, marking it dirty again if
+// that fails so the next attempt retries.
+func (p *ProviderClass) saveDirty(ctx context.Context) diag.Diagnostics {
+	if !deferredSaves.take(p) {
+		return nil
+	}
+	diags := p.save(ctx)
+	if len(diags) > 0 {
+		deferredSaves.markDirty(p)
+	}
+	return diags
+}
+
+func (p *ProviderClass) savePeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-deferredSaves.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			deferredSaves.fail(p, p.saveDirty(ctx))
+			cancel()
+		}
+	}
+}
+
+// SaveDeferred stops the periodic saves, and saves every router with
+// changes pending in the deferred save mode. It is called once the provider
+// has stopped serving, so failures can only be logged.
+func SaveDeferred() {
+	deferredSaves.stopOnce.Do(func() { close(deferredSaves.stop) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, p := range deferredSaves.pending() {
+		wg.Add(1)
+		go func(p *ProviderClass) {
+			defer wg.Done()
+			for _, d := range p.saveDirty(ctx) {
+				log.Printf("[WARN] %s: %s", d.Summary, d.Detail)
+			}
+		}(p)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,228 @@
+package vyos
+
+import (
+	"regexp"
+	"strings"
+)
+
+// showOutput is the structured form of an op-mode show command.
+type showOutput struct {
+	// Key/value lines, e.g. "Version: VyOS 1.4.0" becomes version = "VyOS 1.4.0"
+	Fields map[string]string
+	// Table rows keyed by snake_cased column header
+	Records []map[string]string
+}
+
+// showParsers maps show commands to parsers for their output. Commands
+// without a parser only return raw output.
+var showParsers = map[string]func(string) showOutput{
+	"version":            parseShowFields,
+	"interfaces":         parseShowTable,
+	"dhcp server leases": parseShowTable,
+	"ip route":           parseShowRoutes,
+	"ipv6 route":         parseShowRoutes,
+	"system image":       parseShowImages,
+	"vrrp":               parseShowTable,
+}
+
+func parseShow(command, output string) (showOutput, bool) {
+	parser, ok := showParsers[strings.Join(strings.Fields(command), " ")]
+	if !ok {
+		return showOutput{}, false
+	}
+	return parser(output), true
+}
+
+// showKey converts a header like "IP Address" or "Hardware S/N" to ip_address and hardware_s_n.
+func showKey(header string) string {
+	words := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, "_")
+}
+
+// parseShowFields parses "Key: value" lines.
+func parseShowFields(output string) showOutput {
+	fields := map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		if key := showKey(key); key != "" {
+			if _, exists := fields[key]; !exists {
+				fields[key] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return showOutput{Fields: fields}
+}
+
+// parseShowTable parses fixed width tables with a row of dashes below the
+// header. Rows with an empty first column continue the previous row, and
+// their values are appended with a comma, e.g. the extra addresses in
+// "show interfaces".
+func parseShowTable(output string) showOutput {
+	lines := strings.Split(output, "\n")
+	records := []map[string]string{}
+
+	dashes := -1
+	for i, line := range lines {
+		if i > 0 && strings.Trim(line, "- ") == "" && strings.Contains(line, "-") {
+			dashes = i
+			break
+		}
+	}
+	if dashes < 0 {
+		return showOutput{Records: records}
+	}
+
+	// Columns start where each run of dashes starts
+	starts := []int{}
+	for i, c := range lines[dashes] {
+		if c == '-' && (i == 0 || lines[dashes][i-1] == ' ') {
+			starts = append(starts, i)
+		}
+	}
+	column := func(line string, i int) string {
+		if starts[i] >= len(line) {
+			return ""
+		}
+		end := len(line)
+		if i+1 < len(starts) && starts[i+1] < end {
+			end = starts[i+1]
+		}
+		return strings.TrimSpace(line[starts[i]:end])
+	}
+
+	header := lines[dashes-1]
+	keys := make([]string, len(starts))
+	for i := range starts {
+		keys[i] = showKey(column(header, i))
+	}
+
+	for _, line := range lines[dashes+1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if column(line, 0) == "" && len(records) > 0 {
+			last := records[len(records)-1]
+			for i, key := range keys {
+				if value := column(line, i); value != "" {
+					if last[key] == "" || last[key] == "-" {
+						last[key] = value
+					} else {
+						last[key] += "," + value
+					}
+				}
+			}
+			continue
+		}
+
+		record := map[string]string{}
+		for i, key := range keys {
+			record[key] = column(line, i)
+		}
+		records = append(records, record)
+	}
+
+	return showOutput{Records: records}
+}
+
+var showRouteLine = regexp.MustCompile(`^([A-Za-z])([>*=qrbto ]*?)\s*(\S+/\d+)(?:\s+\[(\d+)/(\d+)\])?\s+(?:via ([^,\s]+)|is directly connected)(?:,\s*([^,\s]+))?(?:,\s*weight \d+)?(?:,\s*(\S+))?`)
+
+var showRouteNexthop = regexp.MustCompile(`^\s+([>*=qrbto ]*?)\s*via ([^,\s]+)(?:,\s*([^,\s]+))?`)
+
+// parseShowRoutes parses the FRR "show ip route" format, one record per
+// route with additional ECMP next hops appended with a comma.
+func parseShowRoutes(output string) showOutput {
+	records := []map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if m := showRouteLine.FindStringSubmatch(line); m != nil {
+			flags := m[2]
+			records = append(records, map[string]string{
+				"protocol":  m[1],
+				"selected":  boolString(strings.Contains(flags, ">")),
+				"fib":       boolString(strings.Contains(flags, "*")),
+				"prefix":    m[3],
+				"distance":  m[4],
+				"metric":    m[5],
+				"nexthop":   m[6],
+				"interface": m[7],
+				"uptime":    m[8],
+			})
+			continue
+		}
+
+		if m := showRouteNexthop.FindStringSubmatch(line); m != nil && len(records) > 0 {
+			last := records[len(records)-1]
+			last["nexthop"] += "," + m[2]
+			last["interface"] += "," + m[3]
+		}
+	}
+	return showOutput{Records: records}
+}
+
+var showImageLine = regexp.MustCompile(`^\s*\d+:\s+(\S+)(.*)$`)
+
+// parseShowImages parses "show system image" into records with name,
+// default_boot and running. 1.4 and later print a table, 1.3 a numbered list.
+func parseShowImages(output string) showOutput {
+	if table := parseShowTable(output); len(table.Records) > 0 {
+		for _, record := range table.Records {
+			record["default_boot"] = boolString(strings.EqualFold(record["default_boot"], "yes"))
+			record["running"] = boolString(strings.EqualFold(record["running"], "yes"))
+		}
+		return table
+	}
+
+	records := []map[string]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if m := showImageLine.FindStringSubmatch(line); m != nil {
+			records = append(records, map[string]string{
+				"name":         m[1],
+				"default_boot": boolString(strings.Contains(m[2], "(default boot)")),
+				"running":      boolString(strings.Contains(m[2], "(running image)")),
+			})
+		}
+	}
+	return showOutput{Records: records}
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+var (
+	showLinkLine  = regexp.MustCompile(`^(\S+?):\s+<([^>]*)>\s+mtu (\d+)`)
+	showInetLine  = regexp.MustCompile(`^\s+inet (\S+?)(?:/\d+)?(?: peer (\S+?)(?:/\d+)?)?\s`)
+	showInet6Line = regexp.MustCompile(`^\s+inet6 (\S+) scope global`)
+)
+
+// parseShowLink parses the "ip addr" style output of "show interfaces <type>
+// <name>" into the fields interface, up, mtu, address, peer and
+// ipv6_addresses, the global IPv6 addresses separated by commas.
+func parseShowLink(output string) showOutput {
+	fields := map[string]string{}
+	ipv6 := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if m := showLinkLine.FindStringSubmatch(line); m != nil {
+			flags := strings.Split(m[2], ",")
+			up := false
+			for _, flag := range flags {
+				up = up || flag == "LOWER_UP"
+			}
+			fields["interface"], fields["up"], fields["mtu"] = m[1], boolString(up), m[3]
+		} else if m := showInetLine.FindStringSubmatch(line); m != nil && fields["address"] == "" {
+			fields["address"], fields["peer"] = m[1], m[2]
+		} else if m := showInet6Line.FindStringSubmatch(line); m != nil {
+			ipv6 = append(ipv6, m[1])
+		}
+	}
+	fields["ipv6_addresses"] = strings.Join(ipv6, ",")
+	return showOutput{Fields: fields}
+}
@@ -0,0 +1,156 @@
+package vyos
+
+import (
+	"reflect"
+	"testing"
+)
+
+// The outputs below follow the format of VyOS 1.3 and 1.4.
+
+const showInterfacesOutput = `Codes: S - State, L - Link, u - Up, D - Down, A - Admin Down
+Interface        IP Address                        S/L  Description
+---------        ----------                        ---  -----------
+eth0             192.0.2.10/24                     u/u  WAN
+                 2001:db8::10/64
+eth1             10.0.0.1/24                       u/u  LAN
+eth2             -                                 A/D
+lo               127.0.0.1/8                       u/u
+                 ::1/128
+`
+
+const showRoutesOutput = `Codes: K - kernel route, C - connected, S - static, R - RIP,
+       O - OSPF, I - IS-IS, B - BGP, E - EIGRP, N - NHRP,
+       T - Table, v - VNC, V - VNC-Direct, A - Babel, F - PBR,
+       f - OpenFabric,
+       > - selected route, * - FIB route, q - queued, r - rejected, b - backup
+       t - trapped, o - offload failure
+
+S>* 0.0.0.0/0 [1/0] via 192.0.2.1, eth0, weight 1, 2d03h41m
+C>* 10.0.0.0/24 is directly connected, eth1, 2d03h41m
+O>* 10.1.0.0/24 [110/20] via 10.0.0.2, eth1, weight 1, 01:02:03
+  *                      via 10.0.0.3, eth2, weight 1, 01:02:03
+`
+
+const showVRRPOutput = `Name    Interface      VRID  State      Priority  Last Transition
+------  -----------  ------  -------  ----------  -----------------
+LAN     eth1             10  MASTER          200  2h3m15s
+WAN     eth0.20           5  BACKUP          100  2h3m10s
+`
+
+const showImages13Output = `The system currently has the following image(s) installed:
+
+   1: 1.3.4 (default boot) (running image)
+   2: 1.3.2
+
+`
+
+const showImages14Output = `Name                  Default boot    Running
+--------------------  --------------  ---------
+1.4.0                 Yes             Yes
+1.3.4
+`
+
+const showPPPoELinkOutput = `pppoe0: <POINTOPOINT,MULTICAST,NOARP,UP,LOWER_UP> mtu 1492 qdisc pfifo_fast state UNKNOWN group default qlen 3
+    link/ppp
+    inet 203.0.113.5 peer 203.0.113.1/32 scope global pppoe0
+       valid_lft forever preferred_lft forever
+    inet6 2001:db8:1::5/64 scope global dynamic mngtmpaddr
+       valid_lft 86396sec preferred_lft 14396sec
+    inet6 fe80::5/128 scope link
+       valid_lft forever preferred_lft forever
+`
+
+func TestParseShow(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser func(string) showOutput
+		output string
+		want   showOutput
+	}{
+		{
+			name:   "version",
+			parser: parseShowFields,
+			output: "Version:          VyOS 1.4.0\nRelease train:    sagitta\nBuilt on:         Mon 01 Jan 2024 00:00 UTC\n",
+			want: showOutput{Fields: map[string]string{
+				"version":       "VyOS 1.4.0",
+				"release_train": "sagitta",
+				"built_on":      "Mon 01 Jan 2024 00:00 UTC",
+			}},
+		},
+		{
+			name:   "interfaces with continuation rows",
+			parser: parseShowTable,
+			output: showInterfacesOutput,
+			want: showOutput{Records: []map[string]string{
+				{"interface": "eth0", "ip_address": "192.0.2.10/24,2001:db8::10/64", "s_l": "u/u", "description": "WAN"},
+				{"interface": "eth1", "ip_address": "10.0.0.1/24", "s_l": "u/u", "description": "LAN"},
+				{"interface": "eth2", "ip_address": "-", "s_l": "A/D", "description": ""},
+				{"interface": "lo", "ip_address": "127.0.0.1/8,::1/128", "s_l": "u/u", "description": ""},
+			}},
+		},
+		{
+			name:   "vrrp with right-aligned columns",
+			parser: parseShowTable,
+			output: showVRRPOutput,
+			want: showOutput{Records: []map[string]string{
+				{"name": "LAN", "interface": "eth1", "vrid": "10", "state": "MASTER", "priority": "200", "last_transition": "2h3m15s"},
+				{"name": "WAN", "interface": "eth0.20", "vrid": "5", "state": "BACKUP", "priority": "100", "last_transition": "2h3m10s"},
+			}},
+		},
+		{
+			name:   "routes with ECMP next hops",
+			parser: parseShowRoutes,
+			output: showRoutesOutput,
+			want: showOutput{Records: []map[string]string{
+				{"protocol": "S", "selected": "true", "fib": "true", "prefix": "0.0.0.0/0", "distance": "1", "metric": "0", "nexthop": "192.0.2.1", "interface": "eth0", "uptime": "2d03h41m"},
+				{"protocol": "C", "selected": "true", "fib": "true", "prefix": "10.0.0.0/24", "distance": "", "metric": "", "nexthop": "", "interface": "eth1", "uptime": "2d03h41m"},
+				{"protocol": "O", "selected": "true", "fib": "true", "prefix": "10.1.0.0/24", "distance": "110", "metric": "20", "nexthop": "10.0.0.2,10.0.0.3", "interface": "eth1,eth2", "uptime": "01:02:03"},
+			}},
+		},
+		{
+			name:   "1.3 images",
+			parser: parseShowImages,
+			output: showImages13Output,
+			want: showOutput{Records: []map[string]string{
+				{"name": "1.3.4", "default_boot": "true", "running": "true"},
+				{"name": "1.3.2", "default_boot": "false", "running": "false"},
+			}},
+		},
+		{
+			name:   "1.4 images",
+			parser: parseShowImages,
+			output: showImages14Output,
+			want: showOutput{Records: []map[string]string{
+				{"name": "1.4.0", "default_boot": "true", "running": "true"},
+				{"name": "1.3.4", "default_boot": "false", "running": "false"},
+			}},
+		},
+		{
+			name:   "pppoe link with a peer",
+			parser: parseShowLink,
+			output: showPPPoELinkOutput,
+			want: showOutput{Fields: map[string]string{
+				"interface":      "pppoe0",
+				"up":             "true",
+				"mtu":            "1492",
+				"address":        "203.0.113.5",
+				"peer":           "203.0.113.1",
+				"ipv6_addresses": "2001:db8:1::5/64",
+			}},
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.parser(tt.output); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %v\nwant %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseShowCommand(t *testing.T) {
+	if _, ok := parseShow("ip   route", showRoutesOutput); !ok {
+		t.Error("no parser for 'ip   route'")
+	}
+	if _, ok := parseShow("ip bgp summary", ""); ok {
+		t.Error("parsed 'ip bgp summary', which has no parser")
+	}
+}
@@ -0,0 +1,375 @@
+package vyos
+
+import (
+	"context"
+	"fmt"
+	pathpkg "path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// vyosVersion is a VyOS release, e.g. 1.4. Rolling releases versioned by
+// date are treated as the latest release.
+type vyosVersion struct {
+	major, minor int
+}
+
+var (
+	vyos13 = vyosVersion{1, 3}
+	vyos14 = vyosVersion{1, 4}
+	vyos15 = vyosVersion{1, 5}
+
+	latestVersion = vyos15
+
+	// syntaxVersions are the releases whose syntax the rules below tell
+	// apart, latest first.
+	syntaxVersions = []vyosVersion{vyos15, vyos14, vyos13}
+)
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// parseVersion accepts the version from "show version", e.g. "VyOS 1.4.0",
+// "1.5-rolling-202401010000" or "2025.01.01-0020-rolling".
+func parseVersion(s string) (vyosVersion, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return vyosVersion{}, fmt.Errorf("unrecognized VyOS version '%s'", s)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	if major >= 2000 {
+		return latestVersion, nil
+	}
+	return vyosVersion{major, minor}, nil
+}
+
+func (v vyosVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+func (v vyosVersion) before(other vyosVersion) bool {
+	return v.major < other.major || (v.major == other.major && v.minor < other.minor)
+}
+
+// pathRule maps a path prefix in the syntax typed resources use, which is
+// that of the latest release, to the syntax of releases before `until`.
+// A "*" matches any single word, "eth*" any word starting with eth. The
+// words matched are substituted in order. The legacy path may end in the
+// value of a leaf node, e.g. "default-route none" for a valueless node.
+type pathRule struct {
+	latest string
+	legacy string
+	until  vyosVersion
+}
+
+var pathRules = []pathRule{
+	// 1.5 moved zone interfaces below member, ahead of the 1.4 rule moving zones
+	{"firewall zone * member interface", "firewall zone * interface", vyos15},
+
+	// 1.4 split firewall rulesets by address family and moved zones under firewall
+	{"firewall ipv4 name *", "firewall name *", vyos14},
+	{"firewall ipv6 name *", "firewall ipv6-name *", vyos14},
+	{"firewall zone", "zone-policy zone", vyos14},
+	{"high-availability vrrp group * address", "high-availability vrrp group * virtual-address", vyos14},
+	{"interfaces openvpn * encryption data-ciphers", "interfaces openvpn * encryption ncp-ciphers", vyos14},
+	{"interfaces pppoe * authentication username", "interfaces pppoe * authentication user", vyos14},
+	{"interfaces pppoe * no-default-route", "interfaces pppoe * default-route none", vyos14},
+	{"nat source rule * outbound-interface name", "nat source rule * outbound-interface", vyos14},
+	{"nat destination rule * inbound-interface name", "nat destination rule * inbound-interface", vyos14},
+	{"policy local-route rule * source address", "policy local-route rule * source", vyos14},
+	{"policy local-route rule * destination address", "policy local-route rule * destination", vyos14},
+	{"policy local-route6 rule * source address", "policy local-route6 rule * source", vyos14},
+	{"policy local-route6 rule * destination address", "policy local-route6 rule * destination", vyos14},
+	{"policy route-map * rule * set as-path prepend", "policy route-map * rule * set as-path-prepend", vyos14},
+	{"policy route-map * rule * set as-path exclude", "policy route-map * rule * set as-path-exclude", vyos14},
+	{"protocols static route * interface", "protocols static interface-route * next-hop-interface", vyos14},
+	{"protocols static route6 * interface", "protocols static interface-route6 * next-hop-interface", vyos14},
+	{"protocols static table * route * interface", "protocols static table * interface-route * next-hop-interface", vyos14},
+	{"protocols static table * route6 * interface", "protocols static table * interface-route6 * next-hop-interface", vyos14},
+	{"qos policy", "traffic-policy", vyos14},
+	// Before 1.4 policies are bound below the interface, whose type follows from its name
+	{"qos interface eth*", "interfaces ethernet * traffic-policy", vyos14},
+	{"qos interface bond*", "interfaces bonding * traffic-policy", vyos14},
+	{"qos interface br*", "interfaces bridge * traffic-policy", vyos14},
+	{"qos interface pppoe*", "interfaces pppoe * traffic-policy", vyos14},
+	{"qos interface vtun*", "interfaces openvpn * traffic-policy", vyos14},
+	{"qos interface tun*", "interfaces tunnel * traffic-policy", vyos14},
+	{"qos interface wg*", "interfaces wireguard * traffic-policy", vyos14},
+	{"qos interface vxlan*", "interfaces vxlan * traffic-policy", vyos14},
+	{"interfaces * * traffic-policy egress", "interfaces * * traffic-policy out", vyos14},
+	{"interfaces * * traffic-policy ingress", "interfaces * * traffic-policy in", vyos14},
+	{"service ntp", "system ntp", vyos14},
+	{"system ntp allow-client", "system ntp allow-clients", vyos14},
+	{"vrf name * protocols static route * interface", "vrf name * protocols static interface-route * next-hop-interface", vyos14},
+	{"vrf name * protocols static route6 * interface", "vrf name * protocols static interface-route6 * next-hop-interface", vyos14},
+
+	// 1.5 renamed the syslog targets
+	{"system syslog remote", "system syslog host", vyos15},
+	{"system syslog local", "system syslog global", vyos15},
+
+	// 1.5 moved DHCP server options below "option"
+	{"service dhcp-server shared-network-name * subnet * option default-router", "service dhcp-server shared-network-name * subnet * default-router", vyos15},
+	{"service dhcp-server shared-network-name * subnet * option name-server", "service dhcp-server shared-network-name * subnet * name-server", vyos15},
+	{"service dhcp-server shared-network-name * subnet * option domain-name", "service dhcp-server shared-network-name * subnet * domain-name", vyos15},
+	{"service dhcp-server shared-network-name * subnet * option domain-search", "service dhcp-server shared-network-name * subnet * domain-search", vyos15},
+}
+
+// pathAvailability lists paths which only exist in some releases, from
+// `since` (inclusive) to `until` (exclusive). Zero means unbounded.
+var pathAvailability = []struct {
+	path         string
+	since, until vyosVersion
+}{
+	{path: "interfaces openvpn * shared-secret-key", since: vyos14},
+	{path: "interfaces openvpn * tls ca-certificate", since: vyos14},
+	{path: "interfaces openvpn * tls certificate", since: vyos14},
+	{path: "interfaces openvpn * tls dh-params", since: vyos14},
+	{path: "interfaces openvpn * tls auth-key", since: vyos14},
+	{path: "interfaces openvpn * tls crypt-key", since: vyos14},
+	{path: "interfaces pppoe * default-route-distance", since: vyos14},
+	{path: "pki", since: vyos14},
+	{path: "policy route-map * rule * set community add", since: vyos14},
+	{path: "policy route-map * rule * set community delete", since: vyos14},
+	{path: "policy route-map * rule * set community replace", since: vyos14},
+	{path: "policy route-map * rule * set community none", since: vyos14},
+	{path: "policy route-map * rule * set large-community add", since: vyos14},
+	{path: "policy route-map * rule * set large-community delete", since: vyos14},
+	{path: "policy route-map * rule * set large-community replace", since: vyos14},
+	{path: "policy route-map * rule * set large-community none", since: vyos14},
+	{path: "protocols ospf interface", since: vyos14},
+	{path: "protocols ospfv3 interface", since: vyos14},
+	{path: "qos interface *.*", since: vyos14},
+	{path: "qos policy cake", since: vyos14},
+	{path: "service dhcp-server shared-network-name * subnet * subnet-id", since: vyos15},
+	{path: "vrf name * protocols ospf interface", since: vyos14},
+	{path: "vrf name * protocols ospfv3", since: vyos14},
+	{path: "system syslog remote * protocol", since: vyos14},
+	{path: "system login user * level", until: vyos14},
+	{path: "vpn ipsec authentication psk", since: vyos14},
+	{path: "vpn ipsec site-to-site peer * remote-address", since: vyos14},
+	{path: "vpn ipsec site-to-site peer * authentication pre-shared-secret", until: vyos14},
+}
+
+// matchPathPrefix returns the words matched by each word containing "*"
+// if pattern is a prefix of path. Such words are glob patterns, e.g. "eth*".
+func matchPathPrefix(pattern, path []string) ([]string, bool) {
+	if len(pattern) > len(path) {
+		return nil, false
+	}
+	wildcards := []string{}
+	for i, word := range pattern {
+		if strings.Contains(word, "*") {
+			if ok, _ := pathpkg.Match(word, path[i]); !ok {
+				return nil, false
+			}
+			wildcards = append(wildcards, path[i])
+		} else if word != path[i] {
+			return nil, false
+		}
+	}
+	return wildcards, true
+}
+
+func rewritePathPrefix(from, to string, path []string) ([]string, bool) {
+	fromWords, toWords := strings.Fields(from), strings.Fields(to)
+	wildcards, ok := matchPathPrefix(fromWords, path)
+	if !ok {
+		return path, false
+	}
+
+	rewritten := []string{}
+	for _, word := range toWords {
+		if strings.Contains(word, "*") {
+			word, wildcards = wildcards[0], wildcards[1:]
+		}
+		rewritten = append(rewritten, word)
+	}
+	return append(rewritten, path[len(fromWords):]...), true
+}
+
+// routerPath translates a path from the latest syntax to that of the
+// router, failing if the router does not support it or its version is
+// unknown.
+func (p *ProviderClass) routerPath(path []string) ([]string, error) {
+	if p.versionErr != nil {
+		return nil, p.versionErr
+	}
+
+	for _, a := range pathAvailability {
+		if _, ok := matchPathPrefix(strings.Fields(a.path), path); !ok {
+			continue
+		}
+		if a.since != (vyosVersion{}) && p.version.before(a.since) {
+			return nil, fmt.Errorf("'%s' is not available on VyOS %s, it requires %s or later", strings.Join(path, " "), p.version, a.since)
+		}
+		if a.until != (vyosVersion{}) && !p.version.before(a.until) {
+			return nil, fmt.Errorf("'%s' is not available on VyOS %s, it was removed in %s", strings.Join(path, " "), p.version, a.until)
+		}
+	}
+
+	for _, rule := range pathRules {
+		if p.version.before(rule.until) {
+			path, _ = rewritePathPrefix(rule.latest, rule.legacy, path)
+		}
+	}
+	return path, nil
+}
+
+// existingVersion returns p for reading or deleting the existing config at
+// path. If the version of the router is unknown, it guesses the version
+// by which translation of path exists on the router, so resources can
+// still be refreshed and destroyed. A wrong guess only affects how nodes
+// below path are read, and changes are not planned without the version.
+func (p *ProviderClass) existingVersion(ctx context.Context, path string) (*ProviderClass, error) {
+	if p.versionErr == nil {
+		return p, nil
+	}
+
+	guesses := []*ProviderClass{}
+	routerPaths := map[string]bool{}
+	for _, version := range syntaxVersions {
+		guess := *p
+		guess.version, guess.versionErr = version, nil
+		routerPath, _, err := guess.routerCommands(path, nil)
+		if err != nil || routerPaths[routerPath] {
+			continue
+		}
+		routerPaths[routerPath] = true
+		guesses = append(guesses, &guess)
+	}
+	if len(guesses) == 0 {
+		return nil, p.versionErr
+	}
+	if len(guesses) == 1 {
+		return guesses[0], nil
+	}
+
+	for _, guess := range guesses {
+		routerPath, _, _ := guess.routerCommands(path, nil)
+		tree, err := p.ShowCached(ctx, routerPath)
+		if err != nil {
+			return nil, err
+		}
+		if tree != nil {
+			return guess, nil
+		}
+	}
+	// Not on the router in any syntax
+	return guesses[0], nil
+}
+
+// latestPath is the inverse of routerPath.
+func (p *ProviderClass) latestPath(path []string) []string {
+	for i := len(pathRules) - 1; i >= 0; i-- {
+		if rule := pathRules[i]; p.version.before(rule.until) {
+			path, _ = rewritePathPrefix(rule.legacy, rule.latest, path)
+		}
+	}
+	return path
+}
+
+// routerCommands translates commands below path to the router syntax,
+// returning the translated path and commands relative to it.
+func (p *ProviderClass) routerCommands(path string, commands []configCommand) (string, []configCommand, error) {
+	base, err := p.routerPath(strings.Fields(path))
+	if err != nil {
+		return "", nil, err
+	}
+
+	translated := []configCommand{}
+	for _, c := range commands {
+		full, err := p.routerPath(append(strings.Fields(path), c.path...))
+		if err != nil {
+			return "", nil, err
+		}
+		if _, ok := matchPathPrefix(base, full); !ok {
+			return "", nil, fmt.Errorf("'%s' moved outside of '%s' on VyOS %s", strings.Join(full, " "), strings.Join(base, " "), p.version)
+		}
+		c.path = full[len(base):]
+		translated = append(translated, c)
+	}
+	return strings.Join(base, " "), translated, nil
+}
+
+// latestConfig translates a config tree read from routerPath back to the
+// latest syntax, relative to path.
+func (p *ProviderClass) latestConfig(path, routerPath string, tree any) map[string]any {
+	if !p.version.before(latestVersion) {
+		return configMap(tree)
+	}
+
+	latest := map[string]any{}
+	base := strings.Fields(path)
+	for _, c := range configTreeCommands(tree, strings.Fields(routerPath)) {
+		full := p.latestPath(c.path)
+		if c.value != "" && !c.multi {
+			// Rules may map a value to a valueless node, e.g.
+			// "default-route none" to "no-default-route"
+			valued := p.latestPath(append(c.path[:len(c.path):len(c.path)], c.value))
+			if strings.Join(valued, " ") != strings.Join(append(full, c.value), " ") {
+				full, c.value = valued, ""
+			}
+		}
+		if _, ok := matchPathPrefix(base, full); ok {
+			c.path = full[len(base):]
+			setConfigTree(latest, c)
+		}
+	}
+	return latest
+}
+
+// configTreeCommands flattens a config tree into the commands setting it.
+func configTreeCommands(tree any, path []string) []configCommand {
+	path = path[:len(path):len(path)]
+
+	switch tree := tree.(type) {
+	case map[string]any:
+		if len(tree) == 0 {
+			return []configCommand{{path: path}}
+		}
+		commands := []configCommand{}
+		for key, child := range tree {
+			commands = append(commands, configTreeCommands(child, append(path, key))...)
+		}
+		return commands
+	case []any:
+		commands := []configCommand{}
+		for _, v := range tree {
+			commands = append(commands, configCommand{path: path, value: fmt.Sprint(v), multi: true})
+		}
+		return commands
+	case nil:
+		return []configCommand{{path: path}}
+	default:
+		return []configCommand{{path: path, value: fmt.Sprint(tree)}}
+	}
+}
+
+// setConfigTree adds a command to a config tree, the inverse of configTreeCommands.
+func setConfigTree(tree map[string]any, c configCommand) {
+	if len(c.path) == 0 {
+		return
+	}
+	for _, word := range c.path[:len(c.path)-1] {
+		child, ok := tree[word].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			tree[word] = child
+		}
+		tree = child
+	}
+
+	leaf := c.path[len(c.path)-1]
+	switch {
+	case c.multi:
+		values, _ := tree[leaf].([]any)
+		tree[leaf] = append(values, c.value)
+	case c.value != "":
+		tree[leaf] = c.value
+	default:
+		if _, ok := tree[leaf].(map[string]any); !ok {
+			tree[leaf] = map[string]any{}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package vyos
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestRouterPath(t *testing.T) {
+	tests := []struct {
+		version vyosVersion
+		latest  string
+		router  string
+		err     string
+	}{
+		// 1.5 moved zone interfaces below member, 1.4 moved zones under firewall
+		{vyos15, "firewall zone LAN member interface eth0", "firewall zone LAN member interface eth0", ""},
+		{vyos14, "firewall zone LAN member interface eth0", "firewall zone LAN interface eth0", ""},
+		{vyos13, "firewall zone LAN member interface eth0", "zone-policy zone LAN interface eth0", ""},
+		{vyos13, "firewall zone LAN default-action drop", "zone-policy zone LAN default-action drop", ""},
+
+		// Before 1.4 policies are bound below the interface
+		{vyos14, "qos interface eth0 egress WAN", "qos interface eth0 egress WAN", ""},
+		{vyos13, "qos interface eth0 egress WAN", "interfaces ethernet eth0 traffic-policy out WAN", ""},
+		{vyos13, "qos interface bond0 ingress LIMIT", "interfaces bonding bond0 traffic-policy in LIMIT", ""},
+
+		// Before 1.4 the default route of a PPPoE session is disabled by value
+		{vyos14, "interfaces pppoe pppoe0 no-default-route", "interfaces pppoe pppoe0 no-default-route", ""},
+		{vyos13, "interfaces pppoe pppoe0 no-default-route", "interfaces pppoe pppoe0 default-route none", ""},
+
+		{vyos13, "pki ca root", "", "'pki ca root' is not available on VyOS 1.3, it requires 1.4 or later"},
+		{vyos14, "system login user admin level admin", "", "'system login user admin level admin' is not available on VyOS 1.4, it was removed in 1.4"},
+		{vyos13, "system login user admin level admin", "system login user admin level admin", ""},
+	}
+	for _, tt := range tests {
+		p := &ProviderClass{version: tt.version}
+		router, err := p.routerPath(strings.Fields(tt.latest))
+		switch {
+		case tt.err != "":
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s on %s: got error %v, want %q", tt.latest, tt.version, err, tt.err)
+			}
+			continue
+		case err != nil:
+			t.Errorf("%s on %s: %s", tt.latest, tt.version, err)
+			continue
+		}
+		if got := strings.Join(router, " "); got != tt.router {
+			t.Errorf("%s on %s: got %q, want %q", tt.latest, tt.version, got, tt.router)
+		}
+		if got := strings.Join(p.latestPath(router), " "); got != tt.latest {
+			t.Errorf("%s on %s: translated back to %q", tt.latest, tt.version, got)
+		}
+	}
+}
+
+func TestLatestConfig(t *testing.T) {
+	p := &ProviderClass{version: vyos13}
+	tree := map[string]any{
+		"default-route": "none",
+		"mtu":           "1492",
+		"authentication": map[string]any{
+			"user": "isp",
+		},
+	}
+	want := map[string]any{
+		"no-default-route": map[string]any{},
+		"mtu":              "1492",
+		"authentication": map[string]any{
+			"username": "isp",
+		},
+	}
+	if got := p.latestConfig("interfaces pppoe pppoe0", "interfaces pppoe pppoe0", tree); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Other values are kept
+	tree["default-route"] = "auto"
+	if got := p.latestConfig("interfaces pppoe pppoe0", "interfaces pppoe pppoe0", tree); got["default-route"] != "auto" {
+		t.Fatalf("got %v, want default-route auto", got)
+	}
+}
+
+func TestRouterPathUnknownVersion(t *testing.T) {
+	versionErr := errors.New("version unknown")
+	p := &ProviderClass{versionErr: versionErr}
+	if _, err := p.routerPath([]string{"service", "ntp"}); !errors.Is(err, versionErr) {
+		t.Fatalf("got error %v, want the version error", err)
+	}
+}
+
+func TestReadUnknownVersion(t *testing.T) {
+	ctx := context.Background()
+	p, router := newTestProvider(t, vyosVersion{}, map[string]any{
+		"zone-policy": map[string]any{"zone": map[string]any{"LAN": map[string]any{
+			"default-action": "drop",
+			"interface":      []any{"eth1"},
+		}}},
+	})
+	p.versionErr = errors.New("version unknown")
+	res := Provider().ResourcesMap["vyos_firewall_zone"]
+
+	state := &terraform.InstanceState{ID: "firewall zone LAN", Attributes: map[string]string{
+		"id":   "firewall zone LAN",
+		"name": "LAN",
+	}}
+	state, diags := res.RefreshWithoutUpgrade(ctx, state, p)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if state == nil || state.ID == "" {
+		t.Fatal("zone read as removed")
+	}
+	if got := state.Attributes["default_action"]; got != "drop" {
+		t.Fatalf("read default_action %q, want drop", got)
+	}
+	if got := state.Attributes["interfaces.#"]; got != "1" {
+		t.Fatalf("read %s interfaces, want 1", got)
+	}
+
+	// Unchanged resources plan without the version, changes are refused
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":           "LAN",
+		"interfaces":     []interface{}{"eth1"},
+		"default_action": "drop",
+	})
+	if _, err := res.Diff(ctx, state, config, p); err != nil {
+		t.Fatalf("unchanged plan: %s", err)
+	}
+	config.Config["default_action"] = "reject"
+	if _, err := res.Diff(ctx, state, config, p); err == nil {
+		t.Fatal("changed plan without the version succeeded")
+	}
+
+	if _, diags := res.Apply(ctx, state, &terraform.InstanceDiff{Destroy: true}, p); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if _, ok := lookupConfig(router.tree, "zone-policy zone LAN"); ok {
+		t.Fatal("zone not deleted")
+	}
+}